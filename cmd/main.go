@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"io/fs"
 	"log"
 	"net/http"
 	"os"
@@ -10,6 +12,7 @@ import (
 	"time"
 
 	"paperwork-service/internal/config"
+	"paperwork-service/internal/database"
 	"paperwork-service/internal/handlers"
 	"paperwork-service/internal/middleware"
 	"paperwork-service/internal/services"
@@ -36,17 +39,43 @@ func main() {
 	logger.Info("Starting Art Battle Paperwork Service",
 		zap.String("version", "1.0.0"),
 		zap.String("environment", cfg.Environment),
-		zap.String("port", cfg.Port))
+		zap.String("port", cfg.Port),
+		zap.String("auth_mode", cfg.AuthMode))
 
 	// Initialize services
-	eventService := services.NewEventService(logger, cfg.SupabaseURL)
-	pdfService := services.NewPaperworkPDFService(logger, cfg.TemplatesPath)
+	eventData := newEventDataProvider(logger, cfg)
+	logger.Info("Event data provider configured", zap.String("provider", eventData.Name()))
+	layout, err := services.LoadLayout(cfg.LayoutConfigPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		logger.Warn("Layout config not found, using the built-in layout", zap.String("path", cfg.LayoutConfigPath))
+	} else if err != nil {
+		logger.Fatal("Invalid layout config", zap.String("path", cfg.LayoutConfigPath), zap.Error(err))
+	}
+	if len(layout.Unused) > 0 {
+		logger.Warn("Layout config has values the service does not draw",
+			zap.String("path", cfg.LayoutConfigPath),
+			zap.Strings("fields", layout.Unused))
+	}
+	documents, err := services.LoadDocuments(cfg.DocumentsPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		logger.Warn("Documents directory not found, no declarative documents loaded", zap.String("path", cfg.DocumentsPath))
+	} else if err != nil {
+		logger.Fatal("Invalid document", zap.String("path", cfg.DocumentsPath), zap.Error(err))
+	}
+	pdfService := services.NewPaperworkPDFService(logger, cfg.TemplatesPath, services.BioPolicy{
+		Prefer:          cfg.BioSource,
+		HistoryFallback: cfg.BioHistoryFallback,
+	}, layout, documents)
+	jobService := services.NewJobService(logger, eventData, pdfService,
+		cfg.JobWorkers, cfg.JobQueueSize, time.Duration(cfg.JobResultTTLMinutes)*time.Minute)
+	jobService.Start()
 
 	// Initialize handlers
-	paperworkHandler := handlers.NewPaperworkHandler(logger, eventService, pdfService)
+	paperworkHandler := handlers.NewPaperworkHandler(logger, eventData, pdfService)
+	jobHandler := handlers.NewJobHandler(logger, jobService)
 
 	// Setup router
-	router := setupRouter(logger, paperworkHandler)
+	router := setupRouter(logger, cfg, paperworkHandler, jobHandler)
 
 	// Create HTTP server
 	srv := &http.Server{
@@ -82,6 +111,11 @@ func main() {
 	} else {
 		logger.Info("Server exited gracefully")
 	}
+
+	// Let in-flight paperwork jobs finish within the same deadline
+	if err := jobService.Shutdown(ctx); err != nil {
+		logger.Error("Jobs cancelled during shutdown", zap.Error(err))
+	}
 }
 
 // initLogger initializes the logger based on environment
@@ -102,21 +136,89 @@ func initLogger(cfg *config.Config) *zap.Logger {
 	return logger
 }
 
+// newEventDataProvider builds the configured event data providers, chained in order if there
+// is more than one
+func newEventDataProvider(logger *zap.Logger, cfg *config.Config) services.EventDataProvider {
+	providers := make([]services.EventDataProvider, 0, len(cfg.DataProviders))
+	for _, name := range cfg.DataProviders {
+		switch name {
+		case config.DataProviderEdge:
+			providers = append(providers, services.NewEventService(logger, cfg.SupabaseURL, services.EdgeClientOptions{
+				APIKey:           cfg.SupabaseKey,
+				AttemptTimeout:   time.Duration(cfg.EdgeAttemptTimeoutSeconds) * time.Second,
+				MaxAttempts:      cfg.EdgeMaxAttempts,
+				MaxResponseBytes: int64(cfg.EdgeMaxResponseMB) << 20,
+				BreakerThreshold: cfg.EdgeBreakerThreshold,
+				BreakerCooldown:  time.Duration(cfg.EdgeBreakerCooldownSeconds) * time.Second,
+			}))
+		case config.DataProviderPostgrest:
+			client, err := database.NewSupabaseClient(cfg, logger)
+			if err != nil {
+				logger.Fatal("Invalid PostgREST provider config", zap.Error(err))
+			}
+			providers = append(providers, client)
+		case config.DataProviderFixtures:
+			providers = append(providers, services.NewFixtureProvider(logger, cfg.FixturesPath))
+		}
+	}
+
+	if len(providers) == 1 {
+		return providers[0]
+	}
+	return services.NewChainedProvider(logger, providers...)
+}
+
 // setupRouter configures the HTTP router with all routes and middleware
-func setupRouter(logger *zap.Logger, paperworkHandler *handlers.PaperworkHandler) http.Handler {
+func setupRouter(logger *zap.Logger, cfg *config.Config, paperworkHandler *handlers.PaperworkHandler, jobHandler *handlers.JobHandler) http.Handler {
 	router := mux.NewRouter()
 
 	// Health check endpoint
 	router.HandleFunc("/api/v1/health", paperworkHandler.HealthCheck).Methods("GET")
 
-	// Public paperwork generation endpoint
-	router.HandleFunc("/api/v1/event-pdf/{eid}", paperworkHandler.GenerateEventPaperwork).Methods("GET")
-
 	// Root redirect
 	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/api/v1/health", http.StatusTemporaryRedirect)
 	}).Methods("GET")
 
+	// Designer previews use the built-in sample event, so they need no authentication
+	router.HandleFunc("/api/v1/preview/{document}", paperworkHandler.PreviewDocument).Methods("GET")
+
+	// Everything below can expose bidder and artist contact details, so it goes through authentication
+	api := router.NewRoute().Subrouter()
+	api.Use(middleware.AuthMiddleware(logger, middleware.AuthOptions{
+		Mode:         cfg.AuthMode,
+		JWTSecret:    cfg.SupabaseJWTSecret,
+		AllowedRoles: cfg.AuthJWTRoles,
+		APIKeys:      cfg.APIKeys,
+	}))
+
+	// Multi-event ZIP export; registered before {eid} so "batch" is not taken as an EID
+	api.HandleFunc("/api/v1/event-pdf/batch", paperworkHandler.GenerateBatchPaperwork).Methods("GET")
+
+	// Paperwork generation endpoint
+	api.HandleFunc("/api/v1/event-pdf/{eid}", paperworkHandler.GenerateEventPaperwork).Methods("GET")
+
+	// Single-page reprints for artist swaps and name corrections
+	api.HandleFunc("/api/v1/event-pdf/{eid}/artists/{entry_id:[0-9]+}", paperworkHandler.GenerateArtistPage).Methods("GET")
+	api.HandleFunc("/api/v1/event-pdf/{eid}/easels/{round:[0-9]+}-{easel:[0-9]+}", paperworkHandler.GenerateEaselPage).Methods("GET")
+
+	// Declarative documents from templates/pdf/documents
+	api.HandleFunc("/api/v1/event-pdf/{eid}/documents/{document}", paperworkHandler.GenerateDocument).Methods("GET")
+
+	// Normalized event data with validation warnings, for checking before printing
+	api.HandleFunc("/api/v1/event-data/{eid}", paperworkHandler.GetEventData).Methods("GET")
+
+	// Spreadsheet exports for finance reconciliation
+	api.HandleFunc("/api/v1/event-csv/{eid}/auction", paperworkHandler.ExportAuctionCSV).Methods("GET")
+	api.HandleFunc("/api/v1/event-csv/{eid}/roster", paperworkHandler.ExportRosterCSV).Methods("GET")
+	api.HandleFunc("/api/v1/event-xlsx/{eid}", paperworkHandler.ExportWorkbook).Methods("GET")
+
+	// Asynchronous paperwork jobs for large events and unreliable connections
+	api.HandleFunc("/api/v1/jobs", jobHandler.CreateJob).Methods("POST")
+	api.HandleFunc("/api/v1/jobs/{id}", jobHandler.GetJob).Methods("GET")
+	api.HandleFunc("/api/v1/jobs/{id}", jobHandler.CancelJob).Methods("DELETE")
+	api.HandleFunc("/api/v1/jobs/{id}/result", jobHandler.GetJobResult).Methods("GET")
+
 	// Apply middleware
 	corsMiddleware := middleware.CORSMiddleware()
 	loggingMiddleware := middleware.LoggingMiddleware(logger)
@@ -125,5 +227,8 @@ func setupRouter(logger *zap.Logger, paperworkHandler *handlers.PaperworkHandler
 	handler := corsMiddleware.Handler(router)
 	handler = loggingMiddleware(handler)
 
+	// Outermost, so the logs, auth failures and error bodies all see the request ID
+	handler = middleware.RequestIDMiddleware()(handler)
+
 	return handler
-}
\ No newline at end of file
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Config holds all configuration for the paperwork service
@@ -12,26 +13,99 @@ type Config struct {
 	Port        string `json:"port"`
 	Environment string `json:"environment"`
 
-	// Supabase configuration
+	// Supabase configuration; required when the edge or postgrest provider is used
 	SupabaseURL string `json:"supabase_url"`
 	SupabaseKey string `json:"supabase_key"`
 
+	// Event data providers, tried in order until one has the event: "edge" (Supabase edge
+	// function), "postgrest" (Supabase tables read directly) or "fixtures" (<EID>.json files
+	// in FixturesPath)
+	DataProviders []string `json:"data_providers"`
+	FixturesPath  string   `json:"fixtures_path"`
+
+	// Edge function client: per-attempt deadline, attempts per call, response size cap and
+	// the circuit breaker's failure threshold and cooldown
+	EdgeAttemptTimeoutSeconds  int `json:"edge_attempt_timeout_seconds"`
+	EdgeMaxAttempts            int `json:"edge_max_attempts"`
+	EdgeMaxResponseMB          int `json:"edge_max_response_mb"`
+	EdgeBreakerThreshold       int `json:"edge_breaker_threshold"`
+	EdgeBreakerCooldownSeconds int `json:"edge_breaker_cooldown_seconds"`
+
+	// Authentication configuration
+	SupabaseJWTSecret string   `json:"-"`
+	APIKeys           []string `json:"-"`
+	AuthMode          string   `json:"auth_mode"`
+	AuthJWTRoles      []string `json:"auth_jwt_roles"`
+
 	// Template paths
 	TemplatesPath   string `json:"templates_path"`
 	FontsPath       string `json:"fonts_path"`
 	BackgroundsPath string `json:"backgrounds_path"`
+	// LayoutConfigPath is the page layout file; missing values fall back to the built-in layout
+	LayoutConfigPath string `json:"layout_config_path"`
+	// DocumentsPath is the directory of declarative document files
+	DocumentsPath string `json:"documents_path"`
+
+	// Bio source policy
+	BioSource          string `json:"bio_source"`
+	BioHistoryFallback bool   `json:"bio_history_fallback"`
+
+	// Async job configuration
+	JobWorkers          int `json:"job_workers"`
+	JobQueueSize        int `json:"job_queue_size"`
+	JobResultTTLMinutes int `json:"job_result_ttl_minutes"`
 }
 
+// Data provider names for DataProviders
+const (
+	DataProviderEdge      = "edge"
+	DataProviderPostgrest = "postgrest"
+	DataProviderFixtures  = "fixtures"
+)
+
 // Load loads configuration from environment variables
 func Load() *Config {
+	dataProviders := getEnvListOf("DATA_PROVIDERS", []string{DataProviderEdge},
+		DataProviderEdge, DataProviderPostgrest, DataProviderFixtures)
+
+	// Supabase credentials are only needed to reach Supabase
+	supabaseURL, supabaseKey := getEnv("SUPABASE_URL", ""), getEnv("SUPABASE_KEY", "")
+	if containsString(dataProviders, DataProviderEdge) || containsString(dataProviders, DataProviderPostgrest) {
+		supabaseURL, supabaseKey = getEnvRequired("SUPABASE_URL"), getEnvRequired("SUPABASE_KEY")
+	}
+
 	return &Config{
-		Port:            getEnv("PORT", "8080"),
-		Environment:     getEnv("ENVIRONMENT", "development"),
-		SupabaseURL:     getEnvRequired("SUPABASE_URL"),
-		SupabaseKey:     getEnvRequired("SUPABASE_KEY"),
-		TemplatesPath:   getEnv("TEMPLATES_PATH", "./templates"),
-		FontsPath:       getEnv("FONTS_PATH", "./templates/fonts"),
-		BackgroundsPath: getEnv("BACKGROUNDS_PATH", "./templates/backgrounds"),
+		Port:        getEnv("PORT", "8080"),
+		Environment: getEnv("ENVIRONMENT", "development"),
+		SupabaseURL: supabaseURL,
+		SupabaseKey: supabaseKey,
+
+		DataProviders: dataProviders,
+		FixturesPath:  getEnv("FIXTURES_PATH", "./fixtures"),
+
+		EdgeAttemptTimeoutSeconds:  getEnvInt("EDGE_ATTEMPT_TIMEOUT_SECONDS", 15),
+		EdgeMaxAttempts:            getEnvInt("EDGE_MAX_ATTEMPTS", 3),
+		EdgeMaxResponseMB:          getEnvInt("EDGE_MAX_RESPONSE_MB", 32),
+		EdgeBreakerThreshold:       getEnvInt("EDGE_BREAKER_THRESHOLD", 5),
+		EdgeBreakerCooldownSeconds: getEnvInt("EDGE_BREAKER_COOLDOWN_SECONDS", 30),
+
+		SupabaseJWTSecret: getEnv("SUPABASE_JWT_SECRET", ""),
+		APIKeys:           getEnvList("API_KEYS"),
+		AuthMode:          getEnvOneOf("AUTH_MODE", "redact", "redact", "required"),
+		AuthJWTRoles:      getEnvList("AUTH_JWT_ROLES", "authenticated", "service_role"),
+
+		TemplatesPath:    getEnv("TEMPLATES_PATH", "./templates"),
+		FontsPath:        getEnv("FONTS_PATH", "./templates/fonts"),
+		BackgroundsPath:  getEnv("BACKGROUNDS_PATH", "./templates/backgrounds"),
+		LayoutConfigPath: getEnv("LAYOUT_CONFIG_PATH", "./templates/pdf/configs/template-config.json"),
+		DocumentsPath:    getEnv("DOCUMENTS_PATH", "./templates/pdf/documents"),
+
+		BioSource:          getEnvOneOf("BIO_SOURCE", "bio", "bio", "abhq"),
+		BioHistoryFallback: getEnvBool("BIO_HISTORY_FALLBACK", true),
+
+		JobWorkers:          getEnvInt("JOB_WORKERS", 2),
+		JobQueueSize:        getEnvInt("JOB_QUEUE_SIZE", 20),
+		JobResultTTLMinutes: getEnvInt("JOB_RESULT_TTL_MINUTES", 60),
 	}
 }
 
@@ -62,6 +136,59 @@ func getEnvRequired(key string) string {
 	return value
 }
 
+// getEnvList gets a comma-separated environment variable as a list, with default values
+func getEnvList(key string, defaultValues ...string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValues
+	}
+
+	list := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvListOf gets a comma-separated environment variable whose items must each be one of
+// the allowed values
+func getEnvListOf(key string, defaultValues []string, allowed ...string) []string {
+	list := getEnvList(key, defaultValues...)
+	if len(list) == 0 {
+		log.Fatalf("Environment variable %s must list at least one of %v", key, allowed)
+	}
+	for _, item := range list {
+		if !containsString(allowed, item) {
+			log.Fatalf("Environment variable %s items must be one of %v, got %q", key, allowed, item)
+		}
+	}
+	return list
+}
+
+// containsString reports whether list contains value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// getEnvOneOf gets an environment variable that must be one of the allowed values
+func getEnvOneOf(key, defaultValue string, allowed ...string) string {
+	value := getEnv(key, defaultValue)
+	for _, option := range allowed {
+		if value == option {
+			return value
+		}
+	}
+	log.Fatalf("Environment variable %s must be one of %v, got %q", key, allowed, value)
+	return ""
+}
+
 // getEnvInt gets an environment variable as an integer
 func getEnvInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
@@ -80,4 +207,4 @@ func getEnvBool(key string, defaultValue bool) bool {
 		}
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
@@ -0,0 +1,247 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"paperwork-service/internal/models"
+)
+
+// contestantRow is a round_contestants row with its person and artist profile embedded
+type contestantRow struct {
+	ID              string            `json:"id"`
+	EventID         string            `json:"event_id"`
+	Round           int               `json:"round"`
+	EaselNumber     int               `json:"easel_number"`
+	PersonID        string            `json:"person_id"`
+	ArtistProfileID string            `json:"artist_profile_id"`
+	Person          *models.Person    `json:"person"`
+	ArtistProfile   *artistProfileRow `json:"artist_profile"`
+}
+
+// artistProfileRow holds the artist_profiles columns the paperwork prints. entry_id is
+// read as raw JSON since older profiles store it as text, not always a number.
+type artistProfileRow struct {
+	ID         string          `json:"id"`
+	EntryID    json.RawMessage `json:"entry_id"`
+	ArtistName string          `json:"artist_name"`
+	Bio        string          `json:"bio"`
+	ABHQBio    string          `json:"abhq_bio"`
+	Website    string          `json:"website"`
+	Instagram  string          `json:"instagram"`
+}
+
+// bidRow is a bids row with the bidder embedded
+type bidRow struct {
+	models.Bid
+	Bidder *models.Person `json:"bidder"`
+}
+
+// historyRow is a round_contestants row from another event, with that event embedded.
+// is_winner is read as raw JSON since it is a flag on some rows and a count on others.
+type historyRow struct {
+	PersonID    string          `json:"person_id"`
+	Round       int             `json:"round"`
+	EaselNumber int             `json:"easel_number"`
+	IsWinner    json.RawMessage `json:"is_winner"`
+	Event       struct {
+		ID                 string `json:"id"`
+		EID                string `json:"eid"`
+		Name               string `json:"name"`
+		EventStartDatetime string `json:"event_start_datetime"`
+	} `json:"event"`
+}
+
+// bid returns the bid with the bidder's name and contact details filled in
+func (r bidRow) bid() models.Bid {
+	bid := r.Bid
+	if bid.BidTime.IsZero() {
+		bid.BidTime = bid.CreatedAt
+	}
+	if r.Bidder != nil {
+		bid.BidderName = r.Bidder.Name
+		if bid.BidderName == "" {
+			bid.BidderName = strings.TrimSpace(r.Bidder.FirstName + " " + r.Bidder.LastName)
+		}
+		bid.BidderEmail = r.Bidder.Email
+		bid.BidderPhone = r.Bidder.Phone
+	}
+	return bid
+}
+
+// winner reports whether is_winner is set, whether stored as a boolean or a number
+func (r historyRow) winner() bool {
+	raw := strings.TrimSpace(string(r.IsWinner))
+	if raw == "true" {
+		return true
+	}
+	n, err := strconv.ParseFloat(raw, 64)
+	return err == nil && n > 0
+}
+
+// entryID returns entry_id as a number, whether stored as a number or as text, or 0
+// when it is missing or not a number
+func (r artistProfileRow) entryID() int {
+	raw := strings.Trim(strings.TrimSpace(string(r.EntryID)), `"`)
+	id, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// personIDs returns the distinct person IDs of the contestants
+func personIDs(contestants []contestantRow) []string {
+	seen := make(map[string]bool)
+	ids := []string{}
+	for _, contestant := range contestants {
+		if contestant.PersonID != "" && !seen[contestant.PersonID] {
+			seen[contestant.PersonID] = true
+			ids = append(ids, contestant.PersonID)
+		}
+	}
+	return ids
+}
+
+// buildEventArtists maps contestants to the artist records the edge function returns
+func buildEventArtists(contestants []contestantRow, history map[string][]models.ArtistEvent) []models.EventArtist {
+	artists := make([]models.EventArtist, 0, len(contestants))
+	for _, contestant := range contestants {
+		artist := models.EventArtist{
+			ContestantID:    contestant.ID,
+			EaselNumber:     contestant.EaselNumber,
+			RoundNumber:     contestant.Round,
+			Round:           contestant.Round,
+			EventID:         contestant.EventID,
+			ArtistProfileID: contestant.ArtistProfileID,
+			EventHistory:    history[contestant.PersonID],
+		}
+
+		if person := contestant.Person; person != nil {
+			artist.PersonName = person.Name
+			artist.FirstName = person.FirstName
+			artist.LastName = person.LastName
+			artist.Email = person.Email
+			artist.Phone = person.Phone
+			if artist.Phone == "" {
+				artist.Phone = person.DisplayPhone
+			}
+		}
+
+		if profile := contestant.ArtistProfile; profile != nil {
+			if artist.ArtistProfileID == "" {
+				artist.ArtistProfileID = profile.ID
+			}
+			artist.EntryID = profile.entryID()
+			artist.ArtistName = profile.ArtistName
+			artist.Bio = profile.Bio
+			artist.ABHQBio = profile.ABHQBio
+			artist.Website = profile.Website
+			artist.Instagram = profile.Instagram
+		}
+
+		artists = append(artists, artist)
+	}
+	return artists
+}
+
+// buildEventHistory groups history rows by person, newest first, leaving out the event
+// being printed and keeping at most historyLimit events each
+func buildEventHistory(rows []historyRow, excludeEventID string) map[string][]models.ArtistEvent {
+	history := make(map[string][]models.ArtistEvent)
+	for _, row := range rows {
+		if row.Event.ID == excludeEventID {
+			continue
+		}
+		// Unparseable dates sort last rather than dropping the event
+		date, _ := time.Parse(time.RFC3339, row.Event.EventStartDatetime)
+		history[row.PersonID] = append(history[row.PersonID], models.ArtistEvent{
+			EventID:     row.Event.ID,
+			EventName:   row.Event.Name,
+			EventEID:    row.Event.EID,
+			EventDate:   date,
+			Round:       row.Round,
+			EaselNumber: row.EaselNumber,
+			IsWinner:    row.winner(),
+		})
+	}
+
+	for personID, events := range history {
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].EventDate.After(events[j].EventDate)
+		})
+		if len(events) > historyLimit {
+			events = events[:historyLimit]
+		}
+		history[personID] = events
+	}
+	return history
+}
+
+// buildAuctionLots aggregates raw bids into one lot per round and easel, the way the
+// edge function does: bids in the order they were placed, the highest amount, and the
+// winning bid (the one flagged as winning, otherwise the highest, earliest on a tie)
+func buildAuctionLots(eventID string, artists []models.EventArtist, bids []models.Bid) []models.AuctionLot {
+	names := make(map[string]string)
+	for _, artist := range artists {
+		name := artist.ArtistName
+		if name == "" {
+			name = artist.PersonName
+		}
+		names[lotKey(artist.RoundNumber, artist.EaselNumber)] = name
+	}
+
+	lotIndex := make(map[string]int)
+	lots := []models.AuctionLot{}
+	for _, bid := range bids {
+		key := lotKey(bid.Round, bid.EaselNumber)
+		i, ok := lotIndex[key]
+		if !ok {
+			i = len(lots)
+			lotIndex[key] = i
+			lots = append(lots, models.AuctionLot{
+				EventID:     eventID,
+				Round:       bid.Round,
+				EaselNumber: bid.EaselNumber,
+				ArtistName:  names[key],
+			})
+		}
+		lots[i].AllBids = append(lots[i].AllBids, bid)
+	}
+
+	for i := range lots {
+		lot := &lots[i]
+		sort.SliceStable(lot.AllBids, func(a, b int) bool {
+			return lot.AllBids[a].BidTime.Before(lot.AllBids[b].BidTime)
+		})
+
+		var winning *models.Bid
+		for j := range lot.AllBids {
+			bid := &lot.AllBids[j]
+			if bid.Amount > lot.HighestBid {
+				lot.HighestBid = bid.Amount
+			}
+			switch {
+			case winning == nil,
+				bid.IsWinning && !winning.IsWinning,
+				bid.IsWinning == winning.IsWinning && bid.Amount > winning.Amount:
+				winning = bid
+			}
+		}
+		lot.BidCount = len(lot.AllBids)
+		if winning != nil {
+			copied := *winning
+			lot.WinningBid = &copied
+		}
+	}
+	return lots
+}
+
+// lotKey identifies a lot by round and easel
+func lotKey(round, easel int) string {
+	return fmt.Sprintf("%d-%d", round, easel)
+}
@@ -2,238 +2,255 @@ package database
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
 	"paperwork-service/internal/config"
 	"paperwork-service/internal/models"
+	"paperwork-service/internal/services"
 
-	"github.com/supabase-community/supabase-go"
 	"go.uber.org/zap"
 )
 
-// SupabaseClient wraps the Supabase client with our business logic
+const (
+	// pageSize is the rows fetched per request; Supabase caps responses at 1000 rows
+	pageSize = 1000
+	// maxResponseBytes caps one page of rows
+	maxResponseBytes = 32 << 20
+	// historyLimit is the most past events listed per artist
+	historyLimit = 10
+)
+
+// SupabaseClient reads event data straight from the Supabase PostgREST API. It assembles
+// the same PaperworkData the paperwork-data edge function returns, so paperwork can still
+// be printed while the edge function is broken.
 type SupabaseClient struct {
-	client *supabase.Client
-	logger *zap.Logger
-	config *config.Config
+	logger     *zap.Logger
+	restURL    string
+	apiKey     string
+	httpClient *http.Client
 }
 
-// NewSupabaseClient creates a new Supabase client wrapper
+// NewSupabaseClient creates a PostgREST client for the project at cfg.SupabaseURL. Each
+// request is cut off after the edge function attempt timeout.
 func NewSupabaseClient(cfg *config.Config, logger *zap.Logger) (*SupabaseClient, error) {
-	client, err := supabase.NewClient(cfg.SupabaseURL, cfg.SupabaseKey, &supabase.ClientOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Supabase client: %w", err)
+	if cfg.SupabaseURL == "" || cfg.SupabaseKey == "" {
+		return nil, errors.New("failed to create Supabase client: SUPABASE_URL and SUPABASE_KEY are required")
+	}
+
+	timeout := time.Duration(cfg.EdgeAttemptTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 15 * time.Second
 	}
 
 	return &SupabaseClient{
-		client: client,
-		logger: logger,
-		config: cfg,
+		logger:     logger,
+		restURL:    strings.TrimRight(cfg.SupabaseURL, "/") + "/rest/v1",
+		apiKey:     cfg.SupabaseKey,
+		httpClient: &http.Client{Timeout: timeout},
 	}, nil
 }
 
-// GetEventByEID fetches an event by its EID (e.g., "AB2995")
-func (s *SupabaseClient) GetEventByEID(ctx context.Context, eid string) (*models.Event, error) {
-	s.logger.Info("Fetching event by EID", zap.String("eid", eid))
+// Name identifies the PostgREST provider in logs
+func (s *SupabaseClient) Name() string {
+	return "postgrest"
+}
+
+// GetEventPaperworkData loads the event, its contestants with their event history and its
+// bids, and builds the auction lots from the raw bids
+func (s *SupabaseClient) GetEventPaperworkData(ctx context.Context, eid string) (*services.PaperworkData, error) {
+	s.logger.Info("Fetching paperwork data from PostgREST", zap.String("eid", eid))
+
+	event, err := s.GetEventByEID(ctx, eid)
+	if err != nil {
+		return nil, err
+	}
+
+	artists, err := s.GetEventArtists(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
 
-	var events []models.Event
-	err := s.client.DB.From("events").
-		Select("*").
-		Eq("eid", eid).
-		Execute(&events)
+	bids, err := s.GetEventBids(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	lots := buildAuctionLots(event.ID, artists, bids)
+
+	s.logger.Info("Successfully assembled paperwork data from PostgREST",
+		zap.String("eid", eid),
+		zap.String("event_name", event.Name),
+		zap.Int("total_artists", len(artists)),
+		zap.Int("total_bids", len(bids)),
+		zap.Int("auction_lots", len(lots)))
+
+	return &services.PaperworkData{
+		Event:        *event,
+		Artists:      artists,
+		AuctionLots:  lots,
+		TotalArtists: len(artists),
+		TotalBids:    len(bids),
+		GeneratedAt:  time.Now().UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// getRows fetches every row of a PostgREST query, a page at a time
+func getRows[T any](ctx context.Context, s *SupabaseClient, table string, query url.Values) ([]T, error) {
+	var rows []T
+	for offset := 0; ; offset += pageSize {
+		query.Set("limit", fmt.Sprint(pageSize))
+		query.Set("offset", fmt.Sprint(offset))
+
+		body, err := s.get(ctx, table, query)
+		if err != nil {
+			return nil, err
+		}
 
+		var page []T
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("%w: failed to parse %s rows: %w", services.ErrUpstreamBadData, table, err)
+		}
+		rows = append(rows, page...)
+
+		if len(page) < pageSize {
+			return rows, nil
+		}
+	}
+}
+
+// get makes one authenticated PostgREST request and returns the response body
+func (s *SupabaseClient) get(ctx context.Context, table string, query url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.restURL+"/"+table+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("apikey", s.apiKey)
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: failed to query %s: %w", services.ErrUpstreamUnavailable, table, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes+1))
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: failed to read %s rows: %w", services.ErrUpstreamUnavailable, table, err)
+	}
+	if len(body) > maxResponseBytes {
+		return nil, fmt.Errorf("%w: %s response over %d bytes", services.ErrUpstreamBadData, table, maxResponseBytes)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		s.logger.Error("PostgREST returned error",
+			zap.String("table", table),
+			zap.Int("status_code", resp.StatusCode),
+			zap.String("response", string(body)))
+		return nil, fmt.Errorf("%w: PostgREST error on %s (status %d): %s",
+			services.ErrUpstreamUnavailable, table, resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
+
+// GetEventByEID fetches an event by its EID (e.g., "AB2995")
+func (s *SupabaseClient) GetEventByEID(ctx context.Context, eid string) (*models.Event, error) {
+	events, err := getRows[models.Event](ctx, s, "events", url.Values{
+		"select": {"*"},
+		"eid":    {"eq." + eid},
+	})
 	if err != nil {
 		s.logger.Error("Failed to fetch event", zap.String("eid", eid), zap.Error(err))
-		return nil, fmt.Errorf("failed to fetch event %s: %w", eid, err)
+		return nil, err
 	}
 
 	if len(events) == 0 {
 		s.logger.Warn("Event not found", zap.String("eid", eid))
-		return nil, fmt.Errorf("event not found: %s", eid)
+		return nil, fmt.Errorf("%w: %s", services.ErrEventNotFound, eid)
 	}
 
 	return &events[0], nil
 }
 
-// GetEventArtists fetches all artists for an event with their profile information
+// GetEventArtists fetches the event's contestants with their person and artist profile,
+// and each artist's recent event history
 func (s *SupabaseClient) GetEventArtists(ctx context.Context, eventID string) ([]models.EventArtist, error) {
-	s.logger.Info("Fetching event artists", zap.String("event_id", eventID))
-
-	query := `
-		*,
-		person:people!inner(
-			id,
-			email,
-			phone,
-			name,
-			first_name,
-			last_name,
-			nickname,
-			display_phone
-		),
-		artist_profile:artist_profiles(
-			id,
-			artist_name,
-			bio,
-			website,
-			instagram,
-			facebook,
-			twitter,
-			tiktok,
-			youtube,
-			profile_image_url
-		)
-	`
-
-	var contestants []struct {
-		models.EventArtist
-		Person        models.Person        `json:"person"`
-		ArtistProfile models.ArtistProfile `json:"artist_profile"`
-	}
-
-	err := s.client.DB.From("round_contestants").
-		Select(query).
-		Eq("event_id", eventID).
-		Order("round", &supabase.OrderOpts{Ascending: true}).
-		Order("easel_number", &supabase.OrderOpts{Ascending: true}).
-		Execute(&contestants)
-
+	contestants, err := getRows[contestantRow](ctx, s, "round_contestants", url.Values{
+		"select":   {"*,person:people(id,email,phone,name,first_name,last_name,nickname,display_phone),artist_profile:artist_profiles(*)"},
+		"event_id": {"eq." + eventID},
+		"order":    {"round.asc,easel_number.asc,id.asc"},
+	})
 	if err != nil {
 		s.logger.Error("Failed to fetch event artists", zap.String("event_id", eventID), zap.Error(err))
-		return nil, fmt.Errorf("failed to fetch event artists: %w", err)
+		return nil, err
 	}
 
-	// Transform the data into our EventArtist model
-	artists := make([]models.EventArtist, len(contestants))
-	for i, contestant := range contestants {
-		artist := contestant.EventArtist
-		artist.Person = contestant.Person
-		artist.ArtistProfile = contestant.ArtistProfile
-
-		// Map fields for easier access in templates
-		artist.FirstName = contestant.Person.FirstName
-		artist.LastName = contestant.Person.LastName
-		artist.Email = contestant.Person.Email
-		artist.Phone = contestant.Person.Phone
-		artist.Instagram = contestant.ArtistProfile.Instagram
-		artist.Bio = contestant.ArtistProfile.Bio
-
-		// TODO: Add city/province/country mapping from related tables if needed
-		artists[i] = artist
+	history, err := s.GetArtistEventHistory(ctx, personIDs(contestants), eventID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// History is a nice-to-have on the artist pages; print without it
+		s.logger.Warn("Failed to fetch artist event history, printing without it",
+			zap.String("event_id", eventID),
+			zap.Error(err))
 	}
 
-	s.logger.Info("Successfully fetched event artists",
-		zap.String("event_id", eventID),
-		zap.Int("count", len(artists)))
-
-	return artists, nil
+	return buildEventArtists(contestants, history), nil
 }
 
 // GetEventBids fetches all bids for an event with bidder information
 func (s *SupabaseClient) GetEventBids(ctx context.Context, eventID string) ([]models.Bid, error) {
-	s.logger.Info("Fetching event bids", zap.String("event_id", eventID))
-
-	query := `
-		*,
-		bidder:people!inner(
-			id,
-			name,
-			email,
-			phone,
-			first_name,
-			last_name
-		)
-	`
-
-	var bidsData []struct {
-		models.Bid
-		Bidder models.Person `json:"bidder"`
-	}
-
-	err := s.client.DB.From("bids").
-		Select(query).
-		Eq("event_id", eventID).
-		Order("round", &supabase.OrderOpts{Ascending: true}).
-		Order("easel_number", &supabase.OrderOpts{Ascending: true}).
-		Order("amount", &supabase.OrderOpts{Ascending: false}).
-		Execute(&bidsData)
-
+	rows, err := getRows[bidRow](ctx, s, "bids", url.Values{
+		"select":   {"*,bidder:people(id,name,email,phone,first_name,last_name)"},
+		"event_id": {"eq." + eventID},
+		"order":    {"round.asc,easel_number.asc,amount.desc,id.asc"},
+	})
 	if err != nil {
 		s.logger.Error("Failed to fetch event bids", zap.String("event_id", eventID), zap.Error(err))
-		return nil, fmt.Errorf("failed to fetch event bids: %w", err)
+		return nil, err
 	}
 
-	// Transform the data
-	bids := make([]models.Bid, len(bidsData))
-	for i, bidData := range bidsData {
-		bid := bidData.Bid
-		bid.BidderName = bidData.Bidder.Name
-		if bid.BidderName == "" {
-			bid.BidderName = fmt.Sprintf("%s %s", bidData.Bidder.FirstName, bidData.Bidder.LastName)
-		}
-		bid.BidderEmail = bidData.Bidder.Email
-		bid.BidderPhone = bidData.Bidder.Phone
-
-		bids[i] = bid
+	bids := make([]models.Bid, len(rows))
+	for i, row := range rows {
+		bids[i] = row.bid()
 	}
-
-	s.logger.Info("Successfully fetched event bids",
-		zap.String("event_id", eventID),
-		zap.Int("count", len(bids)))
-
 	return bids, nil
 }
 
-// GetArtistEventHistory fetches an artist's event participation history
-func (s *SupabaseClient) GetArtistEventHistory(ctx context.Context, personID string) ([]models.ArtistEvent, error) {
-	s.logger.Info("Fetching artist event history", zap.String("person_id", personID))
-
-	query := `
-		round,
-		easel_number,
-		event:events!inner(
-			id,
-			eid,
-			name,
-			event_start_datetime
-		)
-	`
-
-	var historyData []struct {
-		Round       int `json:"round"`
-		EaselNumber int `json:"easel_number"`
-		Event       struct {
-			ID                 string `json:"id"`
-			EID                string `json:"eid"`
-			Name               string `json:"name"`
-			EventStartDatetime string `json:"event_start_datetime"`
-		} `json:"event"`
-	}
-
-	err := s.client.DB.From("round_contestants").
-		Select(query).
-		Eq("person_id", personID).
-		Order("event.event_start_datetime", &supabase.OrderOpts{Ascending: false}).
-		Limit(10). // Limit to last 10 events
-		Execute(&historyData)
+// GetArtistEventHistory fetches the most recent events of each person, other than the
+// event being printed, keyed by person ID
+func (s *SupabaseClient) GetArtistEventHistory(ctx context.Context, personIDs []string, excludeEventID string) (map[string][]models.ArtistEvent, error) {
+	if len(personIDs) == 0 {
+		return nil, nil
+	}
 
+	quoted := make([]string, len(personIDs))
+	for i, id := range personIDs {
+		quoted[i] = `"` + id + `"`
+	}
+	rows, err := getRows[historyRow](ctx, s, "round_contestants", url.Values{
+		"select":    {"*,event:events!inner(id,eid,name,event_start_datetime)"},
+		"person_id": {"in.(" + strings.Join(quoted, ",") + ")"},
+		"order":     {"id.asc"},
+	})
 	if err != nil {
-		s.logger.Error("Failed to fetch artist event history", zap.String("person_id", personID), zap.Error(err))
-		return nil, fmt.Errorf("failed to fetch artist event history: %w", err)
-	}
-
-	history := make([]models.ArtistEvent, len(historyData))
-	for i, item := range historyData {
-		// Parse date string - Supabase returns ISO 8601 format
-		// You might need to adjust this parsing based on the actual format
-		history[i] = models.ArtistEvent{
-			EventID:     item.Event.ID,
-			EventEID:    item.Event.EID,
-			EventName:   item.Event.Name,
-			Round:       item.Round,
-			EaselNumber: item.EaselNumber,
-			// Date parsing would be handled here if needed
-		}
+		return nil, err
 	}
 
-	return history, nil
-}
\ No newline at end of file
+	return buildEventHistory(rows, excludeEventID), nil
+}
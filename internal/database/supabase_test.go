@@ -0,0 +1,372 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"paperwork-service/internal/config"
+	"paperwork-service/internal/models"
+	"paperwork-service/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// postgrestStub serves events, round_contestants and bids rows the way PostgREST does,
+// honouring limit and offset, and records each request as "table offset"
+type postgrestStub struct {
+	t            *testing.T
+	events       []map[string]any
+	contestants  []map[string]any
+	history      []map[string]any
+	bids         []map[string]any
+	historyError bool
+
+	mu       sync.Mutex
+	requests []string
+}
+
+// newClient starts the stub and returns a client for it
+func (p *postgrestStub) newClient() *SupabaseClient {
+	server := httptest.NewServer(p)
+	p.t.Cleanup(server.Close)
+	return newTestClient(p.t, server.URL+"/", "test-key")
+}
+
+// newTestClient creates a client for the project at url
+func newTestClient(t *testing.T, url string, key string) *SupabaseClient {
+	t.Helper()
+	client, err := NewSupabaseClient(&config.Config{SupabaseURL: url, SupabaseKey: key}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func (p *postgrestStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("apikey") != "test-key" || r.Header.Get("Authorization") != "Bearer test-key" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	table := strings.TrimPrefix(r.URL.Path, "/rest/v1/")
+
+	var rows []map[string]any
+	switch {
+	case table == "events":
+		for _, event := range p.events {
+			if query.Get("eid") == "eq."+event["eid"].(string) {
+				rows = append(rows, event)
+			}
+		}
+	case table == "round_contestants" && query.Has("person_id"):
+		table = "history"
+		if p.historyError {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code": "42703", "message": "column round_contestants.is_winner does not exist"}`))
+			return
+		}
+		rows = p.history
+	case table == "round_contestants":
+		rows = p.contestants
+	case table == "bids":
+		rows = p.bids
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	p.mu.Lock()
+	p.requests = append(p.requests, fmt.Sprintf("%s %d", table, offset))
+	p.mu.Unlock()
+
+	if limit != pageSize {
+		p.t.Errorf("%s requested with limit %d, want %d", table, limit, pageSize)
+	}
+	page := []map[string]any{}
+	if offset < len(rows) {
+		page = rows[offset:min(offset+limit, len(rows))]
+	}
+	json.NewEncoder(w).Encode(page)
+}
+
+// stubEvent is the event the stub serves as AB4000, with two artists and their past events
+func stubEvent(t *testing.T) *postgrestStub {
+	return &postgrestStub{
+		t: t,
+		events: []map[string]any{
+			{"id": "ev1", "eid": "AB4000", "name": "Big Night", "currency": "CAD", "event_start_datetime": "2026-05-01T19:00:00Z"},
+		},
+		contestants: []map[string]any{
+			{"id": "c1", "event_id": "ev1", "round": 1, "easel_number": 1, "person_id": "p1", "artist_profile_id": "ap1",
+				"person":         map[string]any{"name": "Ann Person", "email": "ann@example.com"},
+				"artist_profile": map[string]any{"id": "ap1", "entry_id": "310", "artist_name": "Ann Artist", "bio": "Paints."}},
+			{"id": "c2", "event_id": "ev1", "round": 1, "easel_number": 2, "person_id": "p2",
+				"person":         map[string]any{"name": "Ben Brush", "first_name": "Ben", "display_phone": "555-0100"},
+				"artist_profile": map[string]any{"id": "ap2", "entry_id": 311}},
+		},
+		history: []map[string]any{
+			{"person_id": "p1", "round": 1, "easel_number": 1, "is_winner": 1,
+				"event": map[string]any{"id": "ev0", "eid": "AB3000", "name": "Old Night", "event_start_datetime": "2025-05-01T19:00:00+00:00"}},
+			{"person_id": "p1", "round": 1, "easel_number": 1, "is_winner": false,
+				"event": map[string]any{"id": "ev1", "eid": "AB4000", "name": "Big Night", "event_start_datetime": "2026-05-01T19:00:00Z"}},
+			{"person_id": "p1", "round": 2, "easel_number": 3, "is_winner": true,
+				"event": map[string]any{"id": "ev2", "eid": "AB3500", "name": "Newer Night", "event_start_datetime": "2025-09-01T19:00:00Z"}},
+		},
+	}
+}
+
+// stubBids returns n bids on round 1, spread over easels 1 to 3, a second apart
+func stubBids(n int) []map[string]any {
+	bids := make([]map[string]any, n)
+	start := time.Date(2026, 5, 1, 20, 0, 0, 0, time.UTC)
+	for i := range bids {
+		bids[i] = map[string]any{
+			"id": fmt.Sprintf("b%04d", i), "event_id": "ev1", "round": 1, "easel_number": i%3 + 1,
+			"amount": float64(50 + i), "bid_time": start.Add(time.Duration(i) * time.Second).Format(time.RFC3339),
+			"bidder": map[string]any{"first_name": "Bo", "last_name": "Bidder", "email": "bo@example.com"},
+		}
+	}
+	return bids
+}
+
+func TestGetEventPaperworkData(t *testing.T) {
+	stub := stubEvent(t)
+	stub.bids = stubBids(pageSize + 3)
+	data, err := stub.newClient().GetEventPaperworkData(context.Background(), "AB4000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if data.Event.ID != "ev1" || data.Event.Name != "Big Night" {
+		t.Errorf("event = %+v", data.Event)
+	}
+	if data.TotalArtists != 2 || data.TotalBids != pageSize+3 || len(data.AuctionLots) != 3 {
+		t.Errorf("%d artists, %d bids, %d lots, want 2, %d, 3", data.TotalArtists, data.TotalBids, len(data.AuctionLots), pageSize+3)
+	}
+
+	ann, ben := data.Artists[0], data.Artists[1]
+	if ann.ArtistName != "Ann Artist" || ann.EntryID != 310 || ann.PersonName != "Ann Person" || ann.Bio != "Paints." {
+		t.Errorf("first artist = %+v", ann)
+	}
+	if ben.EntryID != 311 || ben.FirstName != "Ben" || ben.Phone != "555-0100" || ben.ArtistProfileID != "ap2" {
+		t.Errorf("second artist = %+v", ben)
+	}
+
+	// The event being printed is left out of the history, newest first
+	var history []string
+	for _, event := range ann.EventHistory {
+		history = append(history, fmt.Sprintf("%s %v", event.EventEID, event.IsWinner))
+	}
+	if strings.Join(history, ", ") != "AB3500 true, AB3000 true" {
+		t.Errorf("history = %q", history)
+	}
+	if len(ben.EventHistory) != 0 {
+		t.Errorf("second artist history = %+v", ben.EventHistory)
+	}
+
+	lot := data.AuctionLots[0]
+	if lot.ArtistName != "Ann Artist" || lot.BidCount != 335 || lot.WinningBid == nil || lot.WinningBid.BidderName != "Bo Bidder" {
+		t.Errorf("first lot = %s %d bids, winning %+v", lot.ArtistName, lot.BidCount, lot.WinningBid)
+	}
+	if data.AuctionLots[1].ArtistName != "Ben Brush" {
+		t.Errorf("lot without an artist name = %q, want the person's name", data.AuctionLots[1].ArtistName)
+	}
+
+	want := "events 0, round_contestants 0, history 0, bids 0, bids 1000"
+	if got := strings.Join(stub.requests, ", "); got != want {
+		t.Errorf("requests = %s, want %s", got, want)
+	}
+}
+
+func TestGetRowsPages(t *testing.T) {
+	for _, n := range []int{0, 1, pageSize - 1, pageSize, pageSize + 1, 2*pageSize + 500} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			stub := stubEvent(t)
+			stub.bids = stubBids(n)
+			bids, err := stub.newClient().GetEventBids(context.Background(), "ev1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(bids) != n {
+				t.Errorf("%d bids, want %d", len(bids), n)
+			}
+			for i, bid := range bids {
+				if bid.ID != fmt.Sprintf("b%04d", i) {
+					t.Fatalf("bid %d is %s: pages out of order or repeated", i, bid.ID)
+				}
+			}
+			// A short page ends the query; a full one asks for the next
+			if want := n/pageSize + 1; len(stub.requests) != want {
+				t.Errorf("%d requests, want %d", len(stub.requests), want)
+			}
+		})
+	}
+}
+
+func TestGetEventPaperworkDataErrors(t *testing.T) {
+	// An event with no row is not found, without querying anything else
+	stub := stubEvent(t)
+	_, err := stub.newClient().GetEventPaperworkData(context.Background(), "AB9999")
+	if !errors.Is(err, services.ErrEventNotFound) {
+		t.Errorf("err = %v, want ErrEventNotFound", err)
+	}
+	if len(stub.requests) != 1 {
+		t.Errorf("requests = %q, want only events", stub.requests)
+	}
+
+	// History is optional
+	stub = stubEvent(t)
+	stub.historyError = true
+	data, err := stub.newClient().GetEventPaperworkData(context.Background(), "AB4000")
+	if err != nil || len(data.Artists) != 2 || len(data.Artists[0].EventHistory) != 0 {
+		t.Errorf("with history failing: %v", err)
+	}
+
+	// Bad credentials
+	server := httptest.NewServer(stubEvent(t))
+	defer server.Close()
+	_, err = newTestClient(t, server.URL, "wrong-key").GetEventPaperworkData(context.Background(), "AB4000")
+	if !errors.Is(err, services.ErrUpstreamUnavailable) || !strings.Contains(err.Error(), "status 401") {
+		t.Errorf("err = %v, want ErrUpstreamUnavailable with the status", err)
+	}
+
+	// Rows that are not an array
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"not": "an array"}`))
+	}))
+	defer server.Close()
+	_, err = newTestClient(t, server.URL, "test-key").GetEventPaperworkData(context.Background(), "AB4000")
+	if !errors.Is(err, services.ErrUpstreamBadData) {
+		t.Errorf("err = %v, want ErrUpstreamBadData", err)
+	}
+}
+
+func TestBuildAuctionLotsWinningBid(t *testing.T) {
+	start := time.Date(2026, 5, 1, 20, 0, 0, 0, time.UTC)
+	bid := func(id string, easel int, amount float64, minute int, winning bool) models.Bid {
+		return models.Bid{ID: id, Round: 1, EaselNumber: easel, Amount: amount,
+			BidTime: start.Add(time.Duration(minute) * time.Minute), IsWinning: winning}
+	}
+	tests := []struct {
+		name        string
+		bids        []models.Bid
+		wantWinning string
+		wantHighest float64
+	}{
+		{"highest", []models.Bid{bid("a", 1, 100, 1, false), bid("b", 1, 150, 2, false), bid("c", 1, 120, 3, false)}, "b", 150},
+		{"equal amounts go to the earliest, whatever the row order", []models.Bid{bid("late", 1, 150, 5, false), bid("early", 1, 150, 2, false)}, "early", 150},
+		{"flagged beats higher", []models.Bid{bid("a", 1, 100, 1, true), bid("b", 1, 150, 2, false)}, "a", 150},
+		{"highest of the flagged", []models.Bid{bid("a", 1, 100, 1, true), bid("b", 1, 130, 2, true), bid("c", 1, 200, 3, false)}, "b", 200},
+		{"equal flagged go to the earliest", []models.Bid{bid("late", 1, 130, 4, true), bid("early", 1, 130, 3, true)}, "early", 130},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lots := buildAuctionLots("ev1", nil, tt.bids)
+			if len(lots) != 1 {
+				t.Fatalf("%d lots, want 1", len(lots))
+			}
+			lot := lots[0]
+			if lot.WinningBid == nil || lot.WinningBid.ID != tt.wantWinning || lot.HighestBid != tt.wantHighest || lot.BidCount != len(tt.bids) {
+				t.Errorf("winning %+v, highest %v, count %d, want %s, %v", lot.WinningBid, lot.HighestBid, lot.BidCount, tt.wantWinning, tt.wantHighest)
+			}
+			for i := 1; i < len(lot.AllBids); i++ {
+				if lot.AllBids[i].BidTime.Before(lot.AllBids[i-1].BidTime) {
+					t.Errorf("bids not in the order they were placed")
+				}
+			}
+		})
+	}
+
+	// Lots follow the bids, one per round and easel, named from the artists
+	artists := []models.EventArtist{{RoundNumber: 1, EaselNumber: 2, ArtistName: "Ann Artist"}, {RoundNumber: 2, EaselNumber: 1, PersonName: "Ben Person"}}
+	lots := buildAuctionLots("ev1", artists, []models.Bid{bid("a", 2, 10, 1, false), bid("b", 1, 10, 1, false), {ID: "c", Round: 2, EaselNumber: 1}})
+	var got []string
+	for _, lot := range lots {
+		got = append(got, fmt.Sprintf("%d-%d %s", lot.Round, lot.EaselNumber, lot.ArtistName))
+	}
+	if strings.Join(got, ", ") != "1-2 Ann Artist, 1-1 , 2-1 Ben Person" {
+		t.Errorf("lots = %q", got)
+	}
+}
+
+func TestBuildEventHistory(t *testing.T) {
+	row := func(personID, eventID, date string) historyRow {
+		r := historyRow{PersonID: personID}
+		r.Event.ID, r.Event.EID, r.Event.EventStartDatetime = eventID, "AB"+eventID, date
+		return r
+	}
+	var rows []historyRow
+	for i := 0; i < historyLimit+5; i++ {
+		rows = append(rows, row("p1", fmt.Sprint(100+i), fmt.Sprintf("2020-01-%02dT19:00:00Z", i+1)))
+	}
+	rows = append(rows,
+		row("p1", "current", "2030-01-01T19:00:00Z"),
+		row("p2", "current", "2030-01-01T19:00:00Z"),
+		row("p3", "no-date", "next Tuesday"),
+		row("p3", "dated", "2020-01-01T19:00:00Z"),
+	)
+
+	history := buildEventHistory(rows, "current")
+	p1 := history["p1"]
+	if len(p1) != historyLimit {
+		t.Fatalf("%d events, want the newest %d", len(p1), historyLimit)
+	}
+	// Newest first, and the event being printed is left out even though it is the newest
+	if p1[0].EventID != "114" || p1[historyLimit-1].EventID != "105" {
+		t.Errorf("history runs %s to %s, want 114 to 105", p1[0].EventID, p1[historyLimit-1].EventID)
+	}
+	if _, ok := history["p2"]; ok {
+		t.Error("an artist with only the current event has history")
+	}
+	if p3 := history["p3"]; len(p3) != 2 || p3[0].EventID != "dated" || p3[1].EventID != "no-date" {
+		t.Errorf("p3 = %+v, want the undated event last", p3)
+	}
+}
+
+func TestDecodeRowsLeniently(t *testing.T) {
+	var contestants []contestantRow
+	if err := json.Unmarshal([]byte(`[
+		{"artist_profile": {"entry_id": 310}},
+		{"artist_profile": {"entry_id": "311"}},
+		{"artist_profile": {"entry_id": "not a number"}},
+		{"artist_profile": {"entry_id": " 312 "}},
+		{"artist_profile": {"entry_id": null}},
+		{"artist_profile": {}}
+	]`), &contestants); err != nil {
+		t.Fatal(err)
+	}
+	var entryIDs []int
+	for _, artist := range buildEventArtists(contestants, nil) {
+		entryIDs = append(entryIDs, artist.EntryID)
+	}
+	if fmt.Sprint(entryIDs) != "[310 311 0 312 0 0]" {
+		t.Errorf("entry IDs = %v", entryIDs)
+	}
+
+	var rows []historyRow
+	if err := json.Unmarshal([]byte(`[
+		{"is_winner": true}, {"is_winner": false},
+		{"is_winner": 1}, {"is_winner": 2}, {"is_winner": 0},
+		{"is_winner": null}, {}, {"is_winner": "true"}
+	]`), &rows); err != nil {
+		t.Fatal(err)
+	}
+	var winners []bool
+	for _, row := range rows {
+		winners = append(winners, row.winner())
+	}
+	if fmt.Sprint(winners) != "[true false true true false false false false]" {
+		t.Errorf("winners = %v", winners)
+	}
+}
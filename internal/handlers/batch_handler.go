@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"paperwork-service/internal/services"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// batchConcurrency bounds how many events are fetched and rendered at once
+	batchConcurrency = 3
+	// maxBatchEvents caps the number of EIDs in one batch request
+	maxBatchEvents = 10
+)
+
+// batchManifest is written to manifest.json at the end of every batch ZIP
+type batchManifest struct {
+	GeneratedAt time.Time            `json:"generated_at"`
+	Sections    []services.Section   `json:"sections"`
+	Profile     services.Profile     `json:"profile"`
+	Succeeded   int                  `json:"succeeded"`
+	Failed      int                  `json:"failed"`
+	Events      []batchManifestEntry `json:"events"`
+}
+
+// batchManifestEntry records the outcome for one event in a batch
+type batchManifestEntry struct {
+	EID       string `json:"eid"`
+	Status    string `json:"status"`
+	File      string `json:"file,omitempty"`
+	EventName string `json:"event_name,omitempty"`
+	SizeBytes int    `json:"size_bytes,omitempty"`
+	Artists   int    `json:"artists,omitempty"`
+	Warnings  int    `json:"warnings,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Code      string `json:"code,omitempty"`
+}
+
+// setError records a failed event with the same message and code the single-event endpoint sends
+func (e *batchManifestEntry) setError(err error) {
+	classified := classifyError(err)
+	e.Error = classified.Message
+	e.Code = classified.Code
+}
+
+// batchResult is the rendered PDF (or failure) for one event
+type batchResult struct {
+	entry   batchManifestEntry
+	pdfData []byte
+}
+
+// GenerateBatchPaperwork streams a ZIP with one PDF per event plus a manifest.json.
+// A failing event is recorded in the manifest and does not fail the batch.
+func (h *PaperworkHandler) GenerateBatchPaperwork(w http.ResponseWriter, r *http.Request) {
+	eids, rejected := parseEIDList(r.URL.Query().Get("eids"))
+	if len(eids)+len(rejected) == 0 {
+		h.respondWithError(w, r, http.StatusBadRequest, "At least one event EID is required, e.g. ?eids=AB2995,AB2996")
+		return
+	}
+	if len(eids)+len(rejected) > maxBatchEvents {
+		h.respondWithError(w, r, http.StatusBadRequest, fmt.Sprintf("At most %d events can be exported in one batch", maxBatchEvents))
+		return
+	}
+
+	sections, err := services.ParseSections(r.URL.Query().Get("sections"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid sections parameter: %v", err))
+		return
+	}
+
+	profile, code, message := requestProfile(r, r.URL.Query().Get("profile"))
+	if code != 0 {
+		h.respondWithError(w, r, code, message)
+		return
+	}
+
+	bioBudget, err := parseBioBudget(r)
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid bio budget: %v", err))
+		return
+	}
+	opts := services.RenderOptions{
+		Sections:  sections,
+		Profile:   profile,
+		BioBudget: bioBudget,
+	}
+
+	h.logger.Info("Generating batch paperwork",
+		zap.Strings("eids", eids),
+		zap.Int("rejected", len(rejected)),
+		zap.Any("sections", sections),
+		zap.String("profile", string(profile)))
+
+	// Render with bounded concurrency; each event gets its own result channel so
+	// the ZIP can be written in request order while later events are still rendering
+	ctx := r.Context()
+	sem := make(chan struct{}, batchConcurrency)
+	results := make([]chan batchResult, len(eids))
+	for i, eid := range eids {
+		results[i] = make(chan batchResult, 1)
+		go func(eid string, out chan<- batchResult) {
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				out <- batchResult{entry: batchManifestEntry{EID: eid, Status: "error", Error: "Request cancelled"}}
+				return
+			}
+			out <- h.renderBatchEvent(ctx, eid, opts)
+		}(eid, results[i])
+	}
+
+	filename := fmt.Sprintf("artbattle_batch_%s.zip", time.Now().UTC().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+
+	manifest := batchManifest{
+		GeneratedAt: time.Now().UTC(),
+		Sections:    sections,
+		Profile:     profile,
+		Events:      make([]batchManifestEntry, 0, len(eids)+len(rejected)),
+	}
+
+	archive := zip.NewWriter(w)
+	for _, out := range results {
+		result := <-out
+		if result.pdfData != nil {
+			if err := writeZipFile(archive, result.entry.File, result.pdfData); err != nil {
+				h.logger.Error("Failed to write batch ZIP entry",
+					zap.String("eid", result.entry.EID),
+					zap.Error(err))
+				return
+			}
+			manifest.Succeeded++
+		} else {
+			manifest.Failed++
+		}
+		manifest.Events = append(manifest.Events, result.entry)
+	}
+	for _, eid := range rejected {
+		manifest.Failed++
+		manifest.Events = append(manifest.Events, batchManifestEntry{
+			EID:    eid,
+			Status: "error",
+			Error:  invalidEIDMessage,
+			Code:   "invalid_request",
+		})
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err == nil {
+		err = writeZipFile(archive, "manifest.json", manifestData)
+	}
+	if err == nil {
+		err = archive.Close()
+	}
+	if err != nil {
+		h.logger.Error("Failed to finish batch ZIP", zap.Error(err))
+		return
+	}
+
+	h.logger.Info("Successfully generated batch paperwork",
+		zap.Int("succeeded", manifest.Succeeded),
+		zap.Int("failed", manifest.Failed))
+}
+
+// renderBatchEvent fetches and renders one event of a batch
+func (h *PaperworkHandler) renderBatchEvent(ctx context.Context, eid string, opts services.RenderOptions) batchResult {
+	entry := batchManifestEntry{EID: eid, Status: "error"}
+
+	data, err := h.eventData.GetEventPaperworkData(ctx, eid)
+	if err != nil {
+		h.logger.Error("Failed to fetch batch event data",
+			zap.String("eid", eid),
+			zap.Error(err))
+		entry.setError(err)
+		return batchResult{entry: entry}
+	}
+
+	warnings := services.NormalizePaperworkData(data)
+	entry.EventName = data.Event.Name
+	entry.Artists = len(data.Artists)
+	entry.Warnings = len(warnings)
+
+	if len(data.Artists) == 0 {
+		entry.setError(services.ErrNoArtists)
+		return batchResult{entry: entry}
+	}
+
+	pdfData, err := h.pdfService.GenerateEventPaperworkContext(ctx, &data.Event, data.Artists, data.AuctionLots, opts)
+	if err != nil {
+		h.logger.Error("Failed to generate batch PDF",
+			zap.String("eid", eid),
+			zap.Error(err))
+		entry.setError(err)
+		return batchResult{entry: entry}
+	}
+
+	entry.Status = "ok"
+	entry.File = fmt.Sprintf("artbattle_%s_paperwork.pdf", eid)
+	entry.SizeBytes = len(pdfData)
+	return batchResult{entry: entry, pdfData: pdfData}
+}
+
+// writeZipFile adds one file to the archive
+func writeZipFile(archive *zip.Writer, name string, data []byte) error {
+	f, err := archive.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// parseEIDList splits a comma-separated EID list, dropping blanks and duplicates. EIDs
+// that are not well-formed are returned separately; they never reach a ZIP entry name.
+func parseEIDList(raw string) (eids []string, rejected []string) {
+	seen := make(map[string]bool)
+	eids = []string{}
+	for _, part := range strings.Split(raw, ",") {
+		eid := strings.TrimSpace(part)
+		if eid == "" || seen[eid] {
+			continue
+		}
+		seen[eid] = true
+		if !services.ValidEID(eid) {
+			rejected = append(rejected, eid)
+			continue
+		}
+		eids = append(eids, eid)
+	}
+	return eids, rejected
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"paperwork-service/internal/services"
+
+	"go.uber.org/zap"
+)
+
+// sampleProvider serves the designer sample event for a fixed set of EIDs
+type sampleProvider map[string]bool
+
+func (p sampleProvider) Name() string {
+	return "sample"
+}
+
+func (p sampleProvider) GetEventPaperworkData(ctx context.Context, eid string) (*services.PaperworkData, error) {
+	if !p[eid] {
+		return nil, fmt.Errorf("%w: %s", services.ErrEventNotFound, eid)
+	}
+	event, artists, lots := services.SampleEvent()
+	event.EID = eid
+	return &services.PaperworkData{Event: *event, Artists: artists, AuctionLots: lots}, nil
+}
+
+func newTestPaperworkHandler(provider services.EventDataProvider) *PaperworkHandler {
+	logger := zap.NewNop()
+	pdfService := services.NewPaperworkPDFService(logger, "../../templates", services.DefaultBioPolicy, services.DefaultLayout(), nil)
+	return NewPaperworkHandler(logger, provider, pdfService)
+}
+
+func TestParseEIDList(t *testing.T) {
+	eids, rejected := parseEIDList(" AB1,,AB2, AB1 ,../../etc/passwd,AB 3,AB_4-x")
+	if want := []string{"AB1", "AB2", "AB_4-x"}; !reflect.DeepEqual(eids, want) {
+		t.Errorf("eids = %q, want %q", eids, want)
+	}
+	if want := []string{"../../etc/passwd", "AB 3"}; !reflect.DeepEqual(rejected, want) {
+		t.Errorf("rejected = %q, want %q", rejected, want)
+	}
+}
+
+func TestBatchRejectsInvalidEIDs(t *testing.T) {
+	h := newTestPaperworkHandler(sampleProvider{"AB1": true})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/event-pdf/batch?sections=auction&eids=AB1,../evil,NOPE", nil)
+	h.GenerateBatchPaperwork(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatalf("response is not a ZIP: %v", err)
+	}
+	var names []string
+	var manifest batchManifest
+	for _, f := range archive.File {
+		names = append(names, f.Name)
+		if f.Name != "manifest.json" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(rc)
+		rc.Close()
+		if err := json.Unmarshal(body, &manifest); err != nil {
+			t.Fatalf("bad manifest: %v", err)
+		}
+	}
+
+	if want := []string{"artbattle_AB1_paperwork.pdf", "manifest.json"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ZIP entries = %q, want %q", names, want)
+	}
+	if manifest.Succeeded != 1 || manifest.Failed != 2 {
+		t.Errorf("manifest counts = %d ok / %d failed, want 1 / 2", manifest.Succeeded, manifest.Failed)
+	}
+
+	codes := make(map[string]string)
+	for _, entry := range manifest.Events {
+		codes[entry.EID] = entry.Code
+	}
+	want := map[string]string{"AB1": "", "NOPE": "event_not_found", "../evil": "invalid_request"}
+	if !reflect.DeepEqual(codes, want) {
+		t.Errorf("manifest codes = %v, want %v", codes, want)
+	}
+}
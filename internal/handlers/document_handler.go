@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"paperwork-service/internal/services"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// GenerateDocument renders one of the declarative documents in templates/pdf/documents for an event.
+// The profile and bio budget parameters work as for the full paperwork.
+func (h *PaperworkHandler) GenerateDocument(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eid := vars["eid"]
+	if message, ok := checkEID(eid); !ok {
+		h.respondWithError(w, r, http.StatusBadRequest, message)
+		return
+	}
+
+	// Check the document before doing any upstream work
+	document := vars["document"]
+	if !h.pdfService.HasDocument(document) {
+		h.respondWithError(w, r, http.StatusNotFound, fmt.Sprintf("Unknown document %q (available: %s)",
+			document, strings.Join(h.pdfService.DocumentNames(), ", ")))
+		return
+	}
+
+	profile, code, message := requestProfile(r, r.URL.Query().Get("profile"))
+	if code != 0 {
+		h.respondWithError(w, r, code, message)
+		return
+	}
+
+	bioBudget, err := parseBioBudget(r)
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid bio budget: %v", err))
+		return
+	}
+
+	h.logger.Info("Generating document for event",
+		zap.String("eid", eid),
+		zap.String("document", document),
+		zap.String("profile", string(profile)))
+
+	data, ok := h.fetchPaperworkData(w, r, eid)
+	if !ok {
+		return
+	}
+
+	if len(data.Artists) == 0 {
+		h.logger.Warn("No artists found for event", zap.String("eid", eid))
+		h.respondWithServiceError(w, r, services.ErrNoArtists)
+		return
+	}
+
+	pdfData, err := h.pdfService.GenerateDocument(r.Context(), document, &data.Event, data.Artists, data.AuctionLots, services.RenderOptions{
+		Profile:   profile,
+		BioBudget: bioBudget,
+	})
+	if err != nil {
+		h.logger.Error("Failed to generate document",
+			zap.String("eid", eid),
+			zap.String("document", document),
+			zap.Error(err))
+		h.respondWithServiceError(w, r, err)
+		return
+	}
+
+	filename := fmt.Sprintf("artbattle_%s_%s.pdf", eid, document)
+	if !h.writePDF(w, eid, filename, pdfData) {
+		return
+	}
+
+	h.logger.Info("Successfully generated document",
+		zap.String("eid", eid),
+		zap.String("document", document),
+		zap.Int("pdf_size_bytes", len(pdfData)))
+}
@@ -0,0 +1,18 @@
+package handlers
+
+import "paperwork-service/internal/services"
+
+// invalidEIDMessage explains a rejected EID, in error responses and batch manifests
+const invalidEIDMessage = "Invalid EID: only letters, digits, '-' and '_' are allowed"
+
+// checkEID checks an event EID from a route or request body before it reaches a data
+// provider, an upstream URL or a file name. On failure it returns the 400 message to send.
+func checkEID(eid string) (string, bool) {
+	if eid == "" {
+		return "Event EID is required", false
+	}
+	if !services.ValidEID(eid) {
+		return invalidEIDMessage, false
+	}
+	return "", true
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"paperwork-service/internal/middleware"
+	"paperwork-service/internal/services"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// refusingProvider fails the test if a request gets as far as fetching event data
+type refusingProvider struct {
+	t *testing.T
+}
+
+func (p refusingProvider) Name() string {
+	return "refusing"
+}
+
+func (p refusingProvider) GetEventPaperworkData(ctx context.Context, eid string) (*services.PaperworkData, error) {
+	p.t.Errorf("provider called with EID %q", eid)
+	return nil, services.ErrEventNotFound
+}
+
+// badEIDs are EIDs as mux decodes them from the path, or as sent in a job body
+var badEIDs = []string{"../../etc/passwd", "AB1/../AB2", "AB 1", "AB1?select=*", "AB1%00", "ÄB1"}
+
+func TestRoutesRejectInvalidEIDs(t *testing.T) {
+	h := newTestPaperworkHandler(refusingProvider{t})
+	routes := []struct {
+		name    string
+		handler http.HandlerFunc
+		vars    map[string]string
+	}{
+		{"event-pdf", h.GenerateEventPaperwork, nil},
+		{"artists", h.GenerateArtistPage, map[string]string{"entry_id": "7"}},
+		{"easels", h.GenerateEaselPage, map[string]string{"round": "1", "easel": "4"}},
+		{"documents", h.GenerateDocument, map[string]string{"document": "bios"}},
+		{"event-data", h.GetEventData, nil},
+		{"event-csv", h.ExportRosterCSV, nil},
+		{"event-xlsx", h.ExportWorkbook, nil},
+	}
+
+	for _, route := range routes {
+		for _, eid := range append([]string{""}, badEIDs...) {
+			vars := map[string]string{"eid": eid}
+			for k, v := range route.vars {
+				vars[k] = v
+			}
+			req := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/", nil), vars)
+			rec := httptest.NewRecorder()
+			route.handler(rec, req)
+
+			var problem middleware.Problem
+			if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil || rec.Code != http.StatusBadRequest || problem.Code != "invalid_request" {
+				t.Errorf("%s with EID %q: %d %s, want 400 invalid_request", route.name, eid, rec.Code, rec.Body)
+			}
+		}
+	}
+}
+
+func TestCreateJobRejectsInvalidEIDs(t *testing.T) {
+	logger := zap.NewNop()
+	pdfService := services.NewPaperworkPDFService(logger, "../../templates", services.DefaultBioPolicy, services.DefaultLayout(), nil)
+	jobs := services.NewJobService(logger, refusingProvider{t}, pdfService, 1, 1, time.Minute)
+	h := NewJobHandler(logger, jobs)
+
+	for _, eid := range append([]string{"", "   "}, badEIDs...) {
+		body, _ := json.Marshal(map[string]string{"eid": eid})
+		rec := httptest.NewRecorder()
+		h.CreateJob(rec, httptest.NewRequest(http.MethodPost, "/api/v1/jobs", strings.NewReader(string(body))))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("job for EID %q: %d %s, want 400", eid, rec.Code, rec.Body)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"paperwork-service/internal/services"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+const (
+	csvContentType  = "text/csv; charset=utf-8"
+	xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// exportWriter renders an export of the event data for a profile into buf
+type exportWriter func(buf *bytes.Buffer, data *services.PaperworkData, profile services.Profile) error
+
+// ExportAuctionCSV exports the auction table of an event as CSV.
+// Pass ?bom=1 to prefix a UTF-8 byte order mark so Excel opens the file cleanly.
+func (h *PaperworkHandler) ExportAuctionCSV(w http.ResponseWriter, r *http.Request) {
+	bom, ok := h.parseBOM(w, r)
+	if !ok {
+		return
+	}
+
+	h.export(w, r, "auction", "csv", csvContentType, func(buf *bytes.Buffer, data *services.PaperworkData, profile services.Profile) error {
+		return services.WriteAuctionCSV(buf, &data.Event, data.Artists, data.AuctionLots, services.CSVOptions{Profile: profile, BOM: bom})
+	})
+}
+
+// ExportRosterCSV exports the artist list of an event as CSV (?bom=1 supported)
+func (h *PaperworkHandler) ExportRosterCSV(w http.ResponseWriter, r *http.Request) {
+	bom, ok := h.parseBOM(w, r)
+	if !ok {
+		return
+	}
+
+	h.export(w, r, "roster", "csv", csvContentType, func(buf *bytes.Buffer, data *services.PaperworkData, profile services.Profile) error {
+		return services.WriteRosterCSV(buf, data.Artists, services.CSVOptions{Profile: profile, BOM: bom})
+	})
+}
+
+// ExportWorkbook exports the event as an .xlsx workbook with Roster, Auction and Bids sheets
+func (h *PaperworkHandler) ExportWorkbook(w http.ResponseWriter, r *http.Request) {
+	h.export(w, r, "workbook", "xlsx", xlsxContentType, func(buf *bytes.Buffer, data *services.PaperworkData, profile services.Profile) error {
+		return services.WriteEventWorkbook(buf, data, services.XLSXOptions{Profile: profile})
+	})
+}
+
+// export handles the shared profile selection, data fetch and download response for exports
+func (h *PaperworkHandler) export(w http.ResponseWriter, r *http.Request, kind string, extension string, contentType string, write exportWriter) {
+	eid := mux.Vars(r)["eid"]
+	if message, ok := checkEID(eid); !ok {
+		h.respondWithError(w, r, http.StatusBadRequest, message)
+		return
+	}
+
+	profile, code, message := requestProfile(r, r.URL.Query().Get("profile"))
+	if code != 0 {
+		h.respondWithError(w, r, code, message)
+		return
+	}
+
+	data, ok := h.fetchPaperworkData(w, r, eid)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := write(&buf, data, profile); err != nil {
+		h.logger.Error("Failed to generate export",
+			zap.String("eid", eid),
+			zap.String("export", kind),
+			zap.Error(err))
+		h.respondWithError(w, r, http.StatusInternalServerError, "Failed to generate export")
+		return
+	}
+
+	filename := fmt.Sprintf("artbattle_%s_%s.%s", eid, kind, extension)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", buf.Len()))
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		h.logger.Error("Failed to write export response",
+			zap.String("eid", eid),
+			zap.Error(err))
+		return
+	}
+
+	h.logger.Info("Successfully exported event data",
+		zap.String("eid", eid),
+		zap.String("export", kind),
+		zap.Int("size_bytes", buf.Len()))
+}
+
+// parseBOM reads the optional ?bom= flag, writing a 400 and returning false if it is invalid
+func (h *PaperworkHandler) parseBOM(w http.ResponseWriter, r *http.Request) (bool, bool) {
+	raw := r.URL.Query().Get("bom")
+	if raw == "" {
+		return false, true
+	}
+
+	bom, err := strconv.ParseBool(raw)
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "Invalid bom parameter, use 1 or 0")
+		return false, false
+	}
+	return bom, true
+}
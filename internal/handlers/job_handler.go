@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"paperwork-service/internal/middleware"
+	"paperwork-service/internal/services"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// maxJobRequestBytes caps the size of a job creation request body
+const maxJobRequestBytes = 64 << 10
+
+// JobHandler handles asynchronous paperwork job requests
+type JobHandler struct {
+	logger     *zap.Logger
+	jobService *services.JobService
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(logger *zap.Logger, jobService *services.JobService) *JobHandler {
+	return &JobHandler{
+		logger:     logger,
+		jobService: jobService,
+	}
+}
+
+// createJobRequest is the body of POST /api/v1/jobs
+type createJobRequest struct {
+	EID      string   `json:"eid"`
+	Sections []string `json:"sections,omitempty"`
+	Profile  string   `json:"profile,omitempty"`
+}
+
+// jobResponse adds resource links to a job status, and for a failed job the same error
+// message and code the synchronous endpoints send
+type jobResponse struct {
+	services.JobStatus
+	Error     string `json:"error,omitempty"`
+	Code      string `json:"code,omitempty"`
+	StatusURL string `json:"status_url"`
+	ResultURL string `json:"result_url,omitempty"`
+}
+
+// CreateJob queues a paperwork job and returns 202 with the job's status URL
+func (h *JobHandler) CreateJob(w http.ResponseWriter, r *http.Request) {
+	var req createJobRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxJobRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid job request: %v", err))
+		return
+	}
+
+	req.EID = strings.TrimSpace(req.EID)
+	if message, ok := checkEID(req.EID); !ok {
+		h.respondWithError(w, r, http.StatusBadRequest, message)
+		return
+	}
+
+	sections, err := services.ParseSections(strings.Join(req.Sections, ","))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid sections: %v", err))
+		return
+	}
+
+	profile, code, message := requestProfile(r, req.Profile)
+	if code != 0 {
+		h.respondWithError(w, r, code, message)
+		return
+	}
+
+	owner := middleware.PrincipalFromContext(r.Context()).ID()
+	status, err := h.jobService.Submit(req.EID, sections, profile, owner)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrJobQueueFull), errors.Is(err, services.ErrJobServiceClosed):
+			w.Header().Set("Retry-After", "30")
+			h.respondWithError(w, r, http.StatusServiceUnavailable, "Job queue is unavailable, try again shortly")
+		default:
+			h.logger.Error("Failed to submit job", zap.String("eid", req.EID), zap.Error(err))
+			h.respondWithError(w, r, http.StatusInternalServerError, "Failed to create job")
+		}
+		return
+	}
+
+	resp := newJobResponse(status)
+	w.Header().Set("Location", resp.StatusURL)
+	h.respondWithJSON(w, http.StatusAccepted, resp)
+}
+
+// GetJob reports a job's status and per-section progress
+func (h *JobHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	status, err := h.jobService.Get(mux.Vars(r)["id"])
+	if err != nil || !jobVisible(r, status) {
+		h.respondWithError(w, r, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, newJobResponse(status))
+}
+
+// GetJobResult downloads the PDF of a finished job
+func (h *JobHandler) GetJobResult(w http.ResponseWriter, r *http.Request) {
+	pdfData, status, err := h.jobService.Result(mux.Vars(r)["id"])
+	switch {
+	case errors.Is(err, services.ErrJobNotFound), !jobVisible(r, status):
+		h.respondWithError(w, r, http.StatusNotFound, "Job not found")
+		return
+	case err != nil:
+		h.respondWithError(w, r, http.StatusConflict, fmt.Sprintf("Job is %s, no result available", status.Status))
+		return
+	}
+
+	// Only public results may be handed to an unauthenticated caller
+	if status.Profile != services.ProfilePublic && !middleware.IsAuthenticated(r.Context()) {
+		h.respondWithError(w, r, http.StatusUnauthorized, "Authentication required for this job result")
+		return
+	}
+
+	filename := fmt.Sprintf("artbattle_%s_paperwork.pdf", status.EID)
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(pdfData)))
+
+	if _, err := w.Write(pdfData); err != nil {
+		h.logger.Error("Failed to write job result",
+			zap.String("job_id", status.ID),
+			zap.Error(err))
+	}
+}
+
+// CancelJob cancels a queued or running job
+func (h *JobHandler) CancelJob(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if status, err := h.jobService.Get(id); err != nil || !jobVisible(r, status) {
+		h.respondWithError(w, r, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	status, err := h.jobService.Cancel(id)
+	if err != nil {
+		h.respondWithError(w, r, http.StatusNotFound, "Job not found")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, newJobResponse(status))
+}
+
+// jobVisible reports whether the caller may see a job. A job created with credentials
+// belongs to that caller; others get 404 as if it did not exist. A job created without
+// credentials is public and open to anyone with its ID.
+func jobVisible(r *http.Request, status services.JobStatus) bool {
+	return status.Owner == "" || status.Owner == middleware.PrincipalFromContext(r.Context()).ID()
+}
+
+// newJobResponse builds the API representation of a job
+func newJobResponse(status services.JobStatus) jobResponse {
+	resp := jobResponse{
+		JobStatus: status,
+		StatusURL: fmt.Sprintf("/api/v1/jobs/%s", status.ID),
+	}
+	if status.Status == services.JobSucceeded {
+		resp.ResultURL = fmt.Sprintf("/api/v1/jobs/%s/result", status.ID)
+	}
+	if status.Status == services.JobFailed && status.Err != nil {
+		classified := classifyError(status.Err)
+		resp.Error = classified.Message
+		resp.Code = classified.Code
+	}
+	return resp
+}
+
+// respondWithJSON sends a JSON response
+func (h *JobHandler) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	respondWithJSON(h.logger, w, code, payload)
+}
+
+// respondWithError sends an error response
+func (h *JobHandler) respondWithError(w http.ResponseWriter, r *http.Request, code int, message string) {
+	respondWithError(h.logger, w, r, code, message)
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"paperwork-service/internal/middleware"
+	"paperwork-service/internal/services"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// providerFunc serves event data from a function
+type providerFunc func(ctx context.Context, eid string) (*services.PaperworkData, error)
+
+func (f providerFunc) Name() string {
+	return "func"
+}
+
+func (f providerFunc) GetEventPaperworkData(ctx context.Context, eid string) (*services.PaperworkData, error) {
+	return f(ctx, eid)
+}
+
+// newTestJobRouter starts a job service for provider and routes the job endpoints to it
+func newTestJobRouter(t *testing.T, provider services.EventDataProvider) *mux.Router {
+	t.Helper()
+	logger := zap.NewNop()
+	pdfService := services.NewPaperworkPDFService(logger, "../../templates", services.DefaultBioPolicy, services.DefaultLayout(), nil)
+	jobs := services.NewJobService(logger, provider, pdfService, 1, 4, time.Minute)
+	jobs.Start()
+	t.Cleanup(func() { jobs.Shutdown(context.Background()) })
+
+	h := NewJobHandler(logger, jobs)
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/jobs", h.CreateJob).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/jobs/{id}", h.GetJob).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/jobs/{id}/result", h.GetJobResult).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/jobs/{id}", h.CancelJob).Methods(http.MethodDelete)
+	return router
+}
+
+// serveJSON sends a request to handler and decodes the JSON response into a map
+func serveJSON(t *testing.T, handler http.Handler, method, path, body string, header http.Header) (int, map[string]interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var resp map[string]interface{}
+	if strings.Contains(rec.Header().Get("Content-Type"), "json") {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s %s: %v: %s", method, path, err, rec.Body)
+		}
+	}
+	return rec.Code, resp
+}
+
+// waitForJob polls a job until it leaves the queued and running states
+func waitForJob(t *testing.T, handler http.Handler, statusURL string, header http.Header) map[string]interface{} {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		code, job := serveJSON(t, handler, http.MethodGet, statusURL, "", header)
+		if code != http.StatusOK {
+			t.Fatalf("GET %s: %d %v", statusURL, code, job)
+		}
+		if job["status"] != services.JobQueued && job["status"] != services.JobRunning {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", statusURL)
+	return nil
+}
+
+func TestFailedJobReportsClassifiedError(t *testing.T) {
+	router := newTestJobRouter(t, providerFunc(func(ctx context.Context, eid string) (*services.PaperworkData, error) {
+		return nil, fmt.Errorf("%w: edge function error (status 500): {\"hint\": \"internal stack trace\"}", services.ErrUpstreamUnavailable)
+	}))
+
+	code, job := serveJSON(t, router, http.MethodPost, "/api/v1/jobs", `{"eid": "AB4000", "profile": "public"}`, nil)
+	if code != http.StatusAccepted {
+		t.Fatalf("create job: %d %v", code, job)
+	}
+	job = waitForJob(t, router, job["status_url"].(string), nil)
+
+	if job["status"] != services.JobFailed || job["code"] != "upstream_unavailable" ||
+		job["error"] != "Event data service is unavailable, try again shortly" {
+		t.Errorf("failed job = %v, want the upstream_unavailable message", job)
+	}
+	body, _ := json.Marshal(job)
+	if strings.Contains(string(body), "stack trace") || strings.Contains(string(body), "status 500") {
+		t.Errorf("job status exposes the upstream response: %s", body)
+	}
+}
+
+func TestJobsBelongToTheirCreator(t *testing.T) {
+	keys := map[string]http.Header{
+		"owner": {"X-Api-Key": {"owner-key"}},
+		"other": {"X-Api-Key": {"other-key"}},
+	}
+	jobs := newTestJobRouter(t, sampleProvider{"AB4000": true})
+	router := middleware.AuthMiddleware(zap.NewNop(), middleware.AuthOptions{
+		Mode:    middleware.AuthModeRequired,
+		APIKeys: []string{"owner-key", "other-key"},
+	})(jobs)
+
+	code, job := serveJSON(t, router, http.MethodPost, "/api/v1/jobs", `{"eid": "AB4000", "sections": ["auction"], "profile": "staff"}`, keys["owner"])
+	if code != http.StatusAccepted {
+		t.Fatalf("create job: %d %v", code, job)
+	}
+	statusURL := job["status_url"].(string)
+	if job = waitForJob(t, router, statusURL, keys["owner"]); job["status"] != services.JobSucceeded {
+		t.Fatalf("job = %v", job)
+	}
+
+	// Another caller cannot tell the job exists, read its staff PDF or cancel it
+	for _, request := range []struct{ method, path string }{
+		{http.MethodGet, statusURL},
+		{http.MethodGet, statusURL + "/result"},
+		{http.MethodDelete, statusURL},
+	} {
+		if code, problem := serveJSON(t, router, request.method, request.path, "", keys["other"]); code != http.StatusNotFound {
+			t.Errorf("other caller %s %s: %d %v, want 404", request.method, request.path, code, problem)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, statusURL+"/result", nil)
+	req.Header.Set("X-API-Key", "owner-key")
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/pdf" {
+		t.Errorf("owner result: %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+}
+
+func TestUnauthenticatedJobsArePublic(t *testing.T) {
+	router := middleware.AuthMiddleware(zap.NewNop(), middleware.AuthOptions{
+		Mode:    middleware.AuthModeRedact,
+		APIKeys: []string{"staff-key"},
+	})(newTestJobRouter(t, sampleProvider{"AB4000": true}))
+
+	code, job := serveJSON(t, router, http.MethodPost, "/api/v1/jobs", `{"eid": "AB4000", "sections": ["auction"]}`, nil)
+	if code != http.StatusAccepted || job["profile"] != string(services.ProfilePublic) {
+		t.Fatalf("create job: %d %v", code, job)
+	}
+	// Anyone with the ID may follow a public job, as before jobs had owners
+	job = waitForJob(t, router, job["status_url"].(string), http.Header{"X-Api-Key": {"staff-key"}})
+	if job["status"] != services.JobSucceeded {
+		t.Errorf("job = %v", job)
+	}
+}
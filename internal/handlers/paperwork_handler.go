@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
+	"paperwork-service/internal/models"
 	"paperwork-service/internal/services"
 
 	"github.com/gorilla/mux"
@@ -13,72 +15,287 @@ import (
 
 // PaperworkHandler handles PDF generation requests
 type PaperworkHandler struct {
-	logger       *zap.Logger
-	eventService *services.EventService
-	pdfService   *services.PaperworkPDFService
+	logger     *zap.Logger
+	eventData  services.EventDataProvider
+	pdfService *services.PaperworkPDFService
 }
 
 // NewPaperworkHandler creates a new paperwork handler
 func NewPaperworkHandler(
 	logger *zap.Logger,
-	eventService *services.EventService,
+	eventData services.EventDataProvider,
 	pdfService *services.PaperworkPDFService,
 ) *PaperworkHandler {
 	return &PaperworkHandler{
-		logger:       logger,
-		eventService: eventService,
-		pdfService:   pdfService,
+		logger:     logger,
+		eventData:  eventData,
+		pdfService: pdfService,
 	}
 }
 
 // GenerateEventPaperwork generates a PDF for the given event EID
 func (h *PaperworkHandler) GenerateEventPaperwork(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
 	// Extract EID from URL path
 	vars := mux.Vars(r)
-	eid, exists := vars["eid"]
-	if !exists || eid == "" {
-		h.respondWithError(w, http.StatusBadRequest, "Event EID is required")
+	eid := vars["eid"]
+	if message, ok := checkEID(eid); !ok {
+		h.respondWithError(w, r, http.StatusBadRequest, message)
+		return
+	}
+
+	// Parse the optional section selection before doing any upstream work
+	sections, err := services.ParseSections(r.URL.Query().Get("sections"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid sections parameter: %v", err))
 		return
 	}
 
-	h.logger.Info("Generating paperwork for event", zap.String("eid", eid))
+	profile, code, message := requestProfile(r, r.URL.Query().Get("profile"))
+	if code != 0 {
+		h.respondWithError(w, r, code, message)
+		return
+	}
 
-	// Fetch all required data from the edge function
-	data, err := h.eventService.GetEventPaperworkData(ctx, eid)
+	bioBudget, err := parseBioBudget(r)
 	if err != nil {
-		h.logger.Error("Failed to fetch event data",
-			zap.String("eid", eid),
-			zap.Error(err))
+		h.respondWithError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid bio budget: %v", err))
+		return
+	}
 
-		if err.Error() == fmt.Sprintf("event not found: %s", eid) {
-			h.respondWithError(w, http.StatusNotFound, "Event not found")
-		} else {
-			h.respondWithError(w, http.StatusInternalServerError, "Failed to fetch event data")
-		}
+	h.logger.Info("Generating paperwork for event",
+		zap.String("eid", eid),
+		zap.Any("sections", sections),
+		zap.String("profile", string(profile)))
+
+	// Fetch all required data from the configured provider
+	data, ok := h.fetchPaperworkData(w, r, eid)
+	if !ok {
 		return
 	}
 
 	// Check if we have any artists
 	if len(data.Artists) == 0 {
 		h.logger.Warn("No artists found for event", zap.String("eid", eid))
-		h.respondWithError(w, http.StatusNotFound, "No artists found for this event")
+		h.respondWithServiceError(w, r, services.ErrNoArtists)
 		return
 	}
 
 	// Generate the PDF
-	pdfData, err := h.pdfService.GenerateEventPaperwork(&data.Event, data.Artists, data.AuctionLots)
+	pdfData, err := h.pdfService.GenerateEventPaperworkContext(r.Context(), &data.Event, data.Artists, data.AuctionLots, services.RenderOptions{
+		Sections:  sections,
+		Profile:   profile,
+		BioBudget: bioBudget,
+	})
 	if err != nil {
 		h.logger.Error("Failed to generate PDF",
 			zap.String("eid", eid),
 			zap.Error(err))
-		h.respondWithError(w, http.StatusInternalServerError, "Failed to generate PDF")
+		h.respondWithServiceError(w, r, err)
 		return
 	}
 
-	// Set response headers for PDF download
+	// Send the PDF as a download
 	filename := fmt.Sprintf("artbattle_%s_paperwork.pdf", eid)
+	if !h.writePDF(w, eid, filename, pdfData) {
+		return
+	}
+
+	h.logger.Info("Successfully generated paperwork PDF",
+		zap.String("eid", eid),
+		zap.String("event_name", data.Event.Name),
+		zap.Int("pdf_size_bytes", len(pdfData)),
+		zap.Int("artist_count", len(data.Artists)),
+		zap.Int("auction_lots", len(data.AuctionLots)))
+}
+
+// GenerateArtistPage generates a single artist page, selected by entry ID, for night-of reprints
+func (h *PaperworkHandler) GenerateArtistPage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eid := vars["eid"]
+	if message, ok := checkEID(eid); !ok {
+		h.respondWithError(w, r, http.StatusBadRequest, message)
+		return
+	}
+
+	entryID, err := strconv.Atoi(vars["entry_id"])
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "Entry ID must be a number")
+		return
+	}
+
+	h.logger.Info("Generating single artist page",
+		zap.String("eid", eid),
+		zap.Int("entry_id", entryID))
+
+	profile, code, message := requestProfile(r, r.URL.Query().Get("profile"))
+	if code != 0 {
+		h.respondWithError(w, r, code, message)
+		return
+	}
+
+	data, ok := h.fetchPaperworkData(w, r, eid)
+	if !ok {
+		return
+	}
+
+	for _, artist := range data.Artists {
+		if artist.EntryID == entryID {
+			filename := fmt.Sprintf("artbattle_%s_artist_%d.pdf", eid, entryID)
+			h.renderArtistPage(w, r, data, artist, filename, profile)
+			return
+		}
+	}
+
+	h.respondWithError(w, r, http.StatusNotFound, fmt.Sprintf("No artist with entry ID %d in this event", entryID))
+}
+
+// GenerateEaselPage generates a single artist page, selected by round and easel, for night-of reprints
+func (h *PaperworkHandler) GenerateEaselPage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eid := vars["eid"]
+	if message, ok := checkEID(eid); !ok {
+		h.respondWithError(w, r, http.StatusBadRequest, message)
+		return
+	}
+
+	round, errRound := strconv.Atoi(vars["round"])
+	easel, errEasel := strconv.Atoi(vars["easel"])
+	if errRound != nil || errEasel != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, "Round and easel must be numbers, e.g. 1-4")
+		return
+	}
+
+	h.logger.Info("Generating single easel page",
+		zap.String("eid", eid),
+		zap.Int("round", round),
+		zap.Int("easel", easel))
+
+	profile, code, message := requestProfile(r, r.URL.Query().Get("profile"))
+	if code != 0 {
+		h.respondWithError(w, r, code, message)
+		return
+	}
+
+	data, ok := h.fetchPaperworkData(w, r, eid)
+	if !ok {
+		return
+	}
+
+	for _, artist := range data.Artists {
+		if artist.RoundNumber == round && artist.EaselNumber == easel {
+			filename := fmt.Sprintf("artbattle_%s_%d-%d.pdf", eid, round, easel)
+			h.renderArtistPage(w, r, data, artist, filename, profile)
+			return
+		}
+	}
+
+	h.respondWithError(w, r, http.StatusNotFound, fmt.Sprintf("No artist at round %d, easel %d in this event", round, easel))
+}
+
+// renderArtistPage renders one artist page and writes it to the response
+func (h *PaperworkHandler) renderArtistPage(w http.ResponseWriter, r *http.Request, data *services.PaperworkData, artist models.EventArtist, filename string, profile services.Profile) {
+	pdfData, err := h.pdfService.GenerateArtistPage(&data.Event, artist, profile)
+	if err != nil {
+		h.logger.Error("Failed to generate artist page",
+			zap.String("eid", data.Event.EID),
+			zap.Int("entry_id", artist.EntryID),
+			zap.Error(err))
+		h.respondWithServiceError(w, r, err)
+		return
+	}
+
+	if !h.writePDF(w, data.Event.EID, filename, pdfData) {
+		return
+	}
+
+	h.logger.Info("Successfully generated artist page",
+		zap.String("eid", data.Event.EID),
+		zap.Int("entry_id", artist.EntryID),
+		zap.Int("round", artist.RoundNumber),
+		zap.Int("easel", artist.EaselNumber),
+		zap.Int("pdf_size_bytes", len(pdfData)))
+}
+
+// GetEventData returns the normalized event data the PDF is built from, with data warnings
+func (h *PaperworkHandler) GetEventData(w http.ResponseWriter, r *http.Request) {
+	eid := mux.Vars(r)["eid"]
+	if message, ok := checkEID(eid); !ok {
+		h.respondWithError(w, r, http.StatusBadRequest, message)
+		return
+	}
+
+	profile, code, message := requestProfile(r, r.URL.Query().Get("profile"))
+	if code != 0 {
+		h.respondWithError(w, r, code, message)
+		return
+	}
+
+	data, warnings, ok := h.fetchNormalizedData(w, r, eid)
+	if !ok {
+		return
+	}
+	services.ApplyProfile(data, profile)
+
+	h.respondWithJSON(w, http.StatusOK, services.NormalizedPaperworkData{
+		PaperworkData: *data,
+		Warnings:      warnings,
+	})
+}
+
+// fetchPaperworkData loads and normalizes event data, writing an error response and returning false on failure
+func (h *PaperworkHandler) fetchPaperworkData(w http.ResponseWriter, r *http.Request, eid string) (*services.PaperworkData, bool) {
+	data, _, ok := h.fetchNormalizedData(w, r, eid)
+	return data, ok
+}
+
+// fetchNormalizedData loads event data and normalizes it, logging any data warnings
+func (h *PaperworkHandler) fetchNormalizedData(w http.ResponseWriter, r *http.Request, eid string) (*services.PaperworkData, []services.DataWarning, bool) {
+	data, err := h.eventData.GetEventPaperworkData(r.Context(), eid)
+	if err != nil {
+		h.logger.Error("Failed to fetch event data",
+			zap.String("eid", eid),
+			zap.Error(err))
+		h.respondWithServiceError(w, r, err)
+		return nil, nil, false
+	}
+
+	warnings := services.NormalizePaperworkData(data)
+	for _, warning := range warnings {
+		h.logger.Warn("Event data warning",
+			zap.String("eid", eid),
+			zap.String("code", warning.Code),
+			zap.String("message", warning.Message))
+	}
+
+	return data, warnings, true
+}
+
+// parseBioBudget reads the optional ?bio_max_chars= and ?bio_max_lines= limits for compact packs
+func parseBioBudget(r *http.Request) (services.BioBudget, error) {
+	var budget services.BioBudget
+	for _, param := range []struct {
+		name  string
+		value *int
+	}{
+		{"bio_max_chars", &budget.MaxChars},
+		{"bio_max_lines", &budget.MaxLines},
+	} {
+		raw := r.URL.Query().Get(param.name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			return services.BioBudget{}, fmt.Errorf("%s must be a positive number", param.name)
+		}
+		*param.value = n
+	}
+	return budget, nil
+}
+
+// writePDF sends PDF bytes as a download, returning false if the write failed
+func (h *PaperworkHandler) writePDF(w http.ResponseWriter, eid string, filename string, pdfData []byte) bool {
+	// Set response headers for PDF download
 	w.Header().Set("Content-Type", "application/pdf")
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
 	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(pdfData)))
@@ -88,15 +305,10 @@ func (h *PaperworkHandler) GenerateEventPaperwork(w http.ResponseWriter, r *http
 		h.logger.Error("Failed to write PDF response",
 			zap.String("eid", eid),
 			zap.Error(err))
-		return
+		return false
 	}
 
-	h.logger.Info("Successfully generated paperwork PDF",
-		zap.String("eid", eid),
-		zap.String("event_name", data.Event.Name),
-		zap.Int("pdf_size_bytes", len(pdfData)),
-		zap.Int("artist_count", len(data.Artists)),
-		zap.Int("auction_lots", len(data.AuctionLots)))
+	return true
 }
 
 // HealthCheck provides a health check endpoint
@@ -112,13 +324,17 @@ func (h *PaperworkHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// respondWithJSON sends a JSON response
+func (h *PaperworkHandler) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	respondWithJSON(h.logger, w, code, payload)
+}
+
 // respondWithError sends an error response
-func (h *PaperworkHandler) respondWithError(w http.ResponseWriter, code int, message string) {
-	h.logger.Error("HTTP error response",
-		zap.Int("status_code", code),
-		zap.String("message", message))
+func (h *PaperworkHandler) respondWithError(w http.ResponseWriter, r *http.Request, code int, message string) {
+	respondWithError(h.logger, w, r, code, message)
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
-	json.NewEncoder(w).Encode(map[string]string{"error": message})
-}
\ No newline at end of file
+// respondWithServiceError sends the error response for a service error
+func (h *PaperworkHandler) respondWithServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	respondWithServiceError(h.logger, w, r, err)
+}
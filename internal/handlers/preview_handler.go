@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"paperwork-service/internal/services"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// previewPaperwork is the preview name of the built-in paperwork pack
+const previewPaperwork = "paperwork"
+
+// PreviewDocument renders a declarative document, or the built-in paperwork as "paperwork",
+// with the synthetic sample event so designers can check backgrounds and layouts without a
+// live event. debug=1 draws a millimetre grid and every field's box and baselines on top.
+func (h *PaperworkHandler) PreviewDocument(w http.ResponseWriter, r *http.Request) {
+	document := mux.Vars(r)["document"]
+	if document != previewPaperwork && !h.pdfService.HasDocument(document) {
+		available := append([]string{previewPaperwork}, h.pdfService.DocumentNames()...)
+		h.respondWithError(w, r, http.StatusNotFound, fmt.Sprintf("Unknown document %q (available: %s)",
+			document, strings.Join(available, ", ")))
+		return
+	}
+
+	query := r.URL.Query()
+	debug := false
+	if raw := query.Get("debug"); raw != "" {
+		var err error
+		if debug, err = strconv.ParseBool(raw); err != nil {
+			h.respondWithError(w, r, http.StatusBadRequest, "Invalid debug parameter: use 1 or 0")
+			return
+		}
+	}
+
+	// The sample event has no real contact or payment details, so any profile is allowed.
+	// Staff is the default as it shows every column.
+	profile, err := services.ParseProfile(query.Get("profile"), services.ProfileStaff)
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid profile: %v", err))
+		return
+	}
+
+	sections, err := services.ParseSections(query.Get("sections"))
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid sections parameter: %v", err))
+		return
+	}
+
+	bioBudget, err := parseBioBudget(r)
+	if err != nil {
+		h.respondWithError(w, r, http.StatusBadRequest, fmt.Sprintf("Invalid bio budget: %v", err))
+		return
+	}
+
+	h.logger.Info("Generating preview",
+		zap.String("document", document),
+		zap.String("profile", string(profile)),
+		zap.Bool("debug", debug))
+
+	event, artists, lots := services.SampleEvent()
+	opts := services.RenderOptions{
+		Sections:  sections,
+		Profile:   profile,
+		BioBudget: bioBudget,
+		Debug:     debug,
+	}
+
+	var pdfData []byte
+	if document == previewPaperwork {
+		pdfData, err = h.pdfService.GenerateEventPaperworkContext(r.Context(), event, artists, lots, opts)
+	} else {
+		pdfData, err = h.pdfService.GenerateDocument(r.Context(), document, event, artists, lots, opts)
+	}
+	if err != nil {
+		h.logger.Error("Failed to generate preview",
+			zap.String("document", document),
+			zap.Error(err))
+		h.respondWithServiceError(w, r, err)
+		return
+	}
+
+	filename := fmt.Sprintf("preview_%s.pdf", document)
+	if !h.writePDF(w, event.EID, filename, pdfData) {
+		return
+	}
+
+	h.logger.Info("Successfully generated preview",
+		zap.String("document", document),
+		zap.Int("pdf_size_bytes", len(pdfData)))
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"paperwork-service/internal/middleware"
+	"paperwork-service/internal/services"
+)
+
+// requestProfile picks the rendering profile for a request. Authenticated callers may
+// choose any profile (default staff); unauthenticated callers only get the public profile.
+// On failure it returns the HTTP status and message to send.
+func requestProfile(r *http.Request, requested string) (services.Profile, int, string) {
+	authenticated := middleware.IsAuthenticated(r.Context())
+
+	defaultProfile := services.ProfilePublic
+	if authenticated {
+		defaultProfile = services.ProfileStaff
+	}
+
+	profile, err := services.ParseProfile(requested, defaultProfile)
+	if err != nil {
+		return "", http.StatusBadRequest, fmt.Sprintf("Invalid profile: %v", err)
+	}
+
+	if !authenticated && profile != services.ProfilePublic {
+		return "", http.StatusUnauthorized, fmt.Sprintf("Authentication required for the %s profile", profile)
+	}
+
+	return profile, 0, ""
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"paperwork-service/internal/middleware"
+	"paperwork-service/internal/services"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// respondWithJSON sends a JSON response
+func respondWithJSON(logger *zap.Logger, w http.ResponseWriter, code int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		logger.Error("Failed to write JSON response", zap.Error(err))
+	}
+}
+
+// statusCodes are the problem codes for errors raised in the handlers themselves
+var statusCodes = map[int]string{
+	http.StatusBadRequest:          "invalid_request",
+	http.StatusUnauthorized:        "unauthorized",
+	http.StatusNotFound:            "not_found",
+	http.StatusConflict:            "conflict",
+	http.StatusServiceUnavailable:  "unavailable",
+	http.StatusInternalServerError: "internal_error",
+}
+
+// respondWithError sends a problem+json error response
+func respondWithError(logger *zap.Logger, w http.ResponseWriter, r *http.Request, code int, message string) {
+	problemCode, ok := statusCodes[code]
+	if !ok {
+		problemCode = "internal_error"
+	}
+	writeProblem(logger, w, r, middleware.Problem{
+		Status: code,
+		Code:   problemCode,
+		Detail: message,
+	}, nil)
+}
+
+// serviceError is how an error from the services package is reported to clients
+type serviceError struct {
+	Status  int
+	Code    string // Machine-readable, stable across message wording changes
+	Title   string // Same for every error with this code
+	Message string // Specific to this failure
+	Section string // The section or document that failed to render, if any
+}
+
+// classifyError maps an error from the services package to its status, code and message
+func classifyError(err error) serviceError {
+	var renderErr *services.RenderError
+	switch {
+	case errors.Is(err, services.ErrEventNotFound):
+		return serviceError{http.StatusNotFound, "event_not_found", "Event not found", "Event not found", ""}
+	case errors.Is(err, services.ErrNoArtists):
+		return serviceError{http.StatusNotFound, "no_artists", "Event has no artists", "No artists found for this event", ""}
+	case errors.Is(err, services.ErrUnknownDocument):
+		return serviceError{http.StatusNotFound, "unknown_document", "Unknown document", "Unknown document", ""}
+	case errors.Is(err, services.ErrUpstreamUnavailable):
+		return serviceError{http.StatusServiceUnavailable, "upstream_unavailable", "Event data service unavailable", "Event data service is unavailable, try again shortly", ""}
+	case errors.Is(err, services.ErrUpstreamBadData):
+		return serviceError{http.StatusBadGateway, "upstream_bad_data", "Invalid event data", "Event data service returned invalid data", ""}
+	case errors.As(err, &renderErr):
+		message := "Failed to generate PDF"
+		if renderErr.Section != "" {
+			message = fmt.Sprintf("Failed to generate PDF (%s)", renderErr.Section)
+		}
+		return serviceError{http.StatusInternalServerError, "render_failed", "PDF rendering failed", message, string(renderErr.Section)}
+	case errors.Is(err, context.DeadlineExceeded):
+		return serviceError{http.StatusGatewayTimeout, "timeout", "Request timed out", "Request timed out", ""}
+	case errors.Is(err, context.Canceled):
+		// Client closed the connection; nobody reads this, but it keeps the logs honest
+		return serviceError{499, "cancelled", "Request cancelled", "Request cancelled", ""}
+	default:
+		return serviceError{http.StatusInternalServerError, "internal_error", "Internal server error", "Internal server error", ""}
+	}
+}
+
+// respondWithServiceError sends the problem+json response for an error from the services package
+func respondWithServiceError(logger *zap.Logger, w http.ResponseWriter, r *http.Request, err error) {
+	e := classifyError(err)
+	writeProblem(logger, w, r, middleware.Problem{
+		Title:   e.Title,
+		Status:  e.Status,
+		Code:    e.Code,
+		Detail:  e.Message,
+		Section: e.Section,
+	}, err)
+}
+
+// writeProblem logs and sends an error response, adding the EID from the route if there is one.
+// Server errors are logged at error level and client errors at warn level.
+func writeProblem(logger *zap.Logger, w http.ResponseWriter, r *http.Request, problem middleware.Problem, cause error) {
+	problem.EID = mux.Vars(r)["eid"]
+
+	fields := []zap.Field{
+		zap.String("request_id", middleware.RequestIDFromContext(r.Context())),
+		zap.Int("status_code", problem.Status),
+		zap.String("code", problem.Code),
+		zap.String("message", problem.Detail),
+	}
+	if cause != nil {
+		fields = append(fields, zap.Error(cause))
+	}
+	// A 4xx is the caller's mistake, not a fault in the service
+	if problem.Status >= http.StatusInternalServerError {
+		logger.Error("HTTP error response", fields...)
+	} else {
+		logger.Warn("HTTP error response", fields...)
+	}
+
+	if err := middleware.WriteProblem(w, r, problem); err != nil {
+		logger.Error("Failed to write error response", zap.Error(err))
+	}
+}
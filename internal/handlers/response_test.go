@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"paperwork-service/internal/services"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestErrorResponseLogLevel(t *testing.T) {
+	tests := []struct {
+		name       string
+		respond    func(logger *zap.Logger, w http.ResponseWriter, r *http.Request)
+		wantStatus int
+		wantLevel  zapcore.Level
+	}{
+		{"bad request", func(logger *zap.Logger, w http.ResponseWriter, r *http.Request) {
+			respondWithError(logger, w, r, http.StatusBadRequest, "Invalid EID")
+		}, http.StatusBadRequest, zapcore.WarnLevel},
+		{"event not found", func(logger *zap.Logger, w http.ResponseWriter, r *http.Request) {
+			respondWithServiceError(logger, w, r, fmt.Errorf("%w: AB4000", services.ErrEventNotFound))
+		}, http.StatusNotFound, zapcore.WarnLevel},
+		{"upstream outage", func(logger *zap.Logger, w http.ResponseWriter, r *http.Request) {
+			respondWithServiceError(logger, w, r, fmt.Errorf("%w: status 503", services.ErrUpstreamUnavailable))
+		}, http.StatusServiceUnavailable, zapcore.ErrorLevel},
+		{"unexpected error", func(logger *zap.Logger, w http.ResponseWriter, r *http.Request) {
+			respondWithServiceError(logger, w, r, errors.New("boom"))
+		}, http.StatusInternalServerError, zapcore.ErrorLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.DebugLevel)
+			rec := httptest.NewRecorder()
+			tt.respond(zap.New(core), rec, httptest.NewRequest(http.MethodGet, "/api/v1/event-pdf/AB4000", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			entries := logs.FilterMessage("HTTP error response").All()
+			if len(entries) != 1 || entries[0].Level != tt.wantLevel {
+				t.Fatalf("logged %+v, want one entry at %s", entries, tt.wantLevel)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package middleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Authentication modes for callers that present no credentials
+const (
+	// AuthModeRequired rejects unauthenticated callers with 401
+	AuthModeRequired = "required"
+	// AuthModeRedact lets unauthenticated callers through; handlers serve a PII-free variant
+	AuthModeRedact = "redact"
+)
+
+// Principal describes the caller of a request
+type Principal struct {
+	Authenticated bool
+	Method        string // "jwt" or "api_key"
+	Subject       string // The JWT sub claim, or a fingerprint of the API key
+	Role          string
+}
+
+// ID identifies an authenticated caller across requests, e.g. to own the jobs it creates.
+// It is empty for unauthenticated callers.
+func (p Principal) ID() string {
+	if !p.Authenticated {
+		return ""
+	}
+	return p.Method + ":" + p.Subject
+}
+
+// principalKey is the context key for the request Principal
+type principalKey struct{}
+
+// PrincipalFromContext returns the caller set by AuthMiddleware (zero value if none)
+func PrincipalFromContext(ctx context.Context) Principal {
+	principal, _ := ctx.Value(principalKey{}).(Principal)
+	return principal
+}
+
+// IsAuthenticated reports whether the request was made with valid credentials
+func IsAuthenticated(ctx context.Context) bool {
+	return PrincipalFromContext(ctx).Authenticated
+}
+
+// AuthOptions configures AuthMiddleware
+type AuthOptions struct {
+	Mode         string   // AuthModeRequired or AuthModeRedact
+	JWTSecret    string   // Supabase project JWT secret (HS256)
+	AllowedRoles []string // JWT role claims that grant access, e.g. "authenticated"
+	APIKeys      []string // Static API keys accepted via X-API-Key or Authorization: Bearer
+}
+
+// AuthMiddleware authenticates callers with a Supabase-issued JWT or a static API key.
+// Invalid credentials are always rejected; missing credentials are rejected only in required mode.
+func AuthMiddleware(logger *zap.Logger, opts AuthOptions) func(http.Handler) http.Handler {
+	if opts.JWTSecret == "" && len(opts.APIKeys) == 0 {
+		logger.Warn("No JWT secret or API keys configured, every caller is unauthenticated",
+			zap.String("auth_mode", opts.Mode))
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := credentialFromRequest(r)
+			if token == "" {
+				if opts.Mode == AuthModeRequired {
+					respondUnauthorized(w, r, "Authentication required")
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal, err := authenticate(token, opts, time.Now())
+			if err != nil {
+				logger.Warn("Rejected credentials",
+					zap.String("path", r.URL.Path),
+					zap.String("remote_addr", r.RemoteAddr),
+					zap.Error(err))
+				respondUnauthorized(w, r, "Invalid credentials")
+				return
+			}
+			if !principal.Authenticated && opts.Mode == AuthModeRequired {
+				respondUnauthorized(w, r, "Authentication required")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), principalKey{}, principal)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// credentialFromRequest reads an API key or bearer token from the request headers
+func credentialFromRequest(r *http.Request) string {
+	if key := strings.TrimSpace(r.Header.Get("X-API-Key")); key != "" {
+		return key
+	}
+	auth := r.Header.Get("Authorization")
+	if len(auth) > 7 && strings.EqualFold(auth[:7], "Bearer ") {
+		return strings.TrimSpace(auth[7:])
+	}
+	return ""
+}
+
+// authenticate checks a credential against the static API keys, then as a JWT.
+// It errors only for credentials that are invalid, not for valid ones lacking access.
+func authenticate(token string, opts AuthOptions, now time.Time) (Principal, error) {
+	for _, key := range opts.APIKeys {
+		if key != "" && subtle.ConstantTimeCompare([]byte(token), []byte(key)) == 1 {
+			return Principal{Authenticated: true, Method: "api_key", Subject: apiKeyID(key)}, nil
+		}
+	}
+
+	if opts.JWTSecret == "" {
+		return Principal{}, errors.New("unknown API key")
+	}
+
+	claims, err := verifyHS256(token, opts.JWTSecret, now)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	// A genuine token with another role (e.g. the public anon key) is treated as no credentials
+	if !containsString(opts.AllowedRoles, claims.Role) {
+		return Principal{Method: "jwt", Subject: claims.Subject, Role: claims.Role}, nil
+	}
+
+	return Principal{
+		Authenticated: true,
+		Method:        "jwt",
+		Subject:       claims.Subject,
+		Role:          claims.Role,
+	}, nil
+}
+
+// apiKeyID is a fingerprint that tells API keys apart without keeping the key itself
+func apiKeyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// jwtClaims holds the Supabase JWT claims we check
+type jwtClaims struct {
+	Subject   string `json:"sub"`
+	Role      string `json:"role"`
+	ExpiresAt *int64 `json:"exp"`
+	NotBefore *int64 `json:"nbf"`
+}
+
+// verifyHS256 validates a compact JWT signed with HMAC-SHA256 and returns its claims
+func verifyHS256(token string, secret string, now time.Time) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token header: %w", err)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, fmt.Errorf("malformed token header: %w", err)
+	}
+	if header.Alg != "HS256" {
+		return nil, fmt.Errorf("unsupported token algorithm %q", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errors.New("invalid token signature")
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token claims: %w", err)
+	}
+	var claims jwtClaims
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, fmt.Errorf("malformed token claims: %w", err)
+	}
+
+	// Allow a little clock skew between us and Supabase
+	const leeway = 30 * time.Second
+	if claims.ExpiresAt == nil {
+		return nil, errors.New("token has no expiry")
+	}
+	if now.Add(-leeway).After(time.Unix(*claims.ExpiresAt, 0)) {
+		return nil, errors.New("token has expired")
+	}
+	if claims.NotBefore != nil && now.Add(leeway).Before(time.Unix(*claims.NotBefore, 0)) {
+		return nil, errors.New("token is not valid yet")
+	}
+
+	return &claims, nil
+}
+
+// respondUnauthorized sends a 401 with a bearer challenge
+func respondUnauthorized(w http.ResponseWriter, r *http.Request, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="paperwork-service"`)
+	WriteProblem(w, r, Problem{
+		Status: http.StatusUnauthorized,
+		Code:   "unauthorized",
+		Detail: message,
+	})
+}
+
+// containsString reports whether list contains value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,238 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const testSecret = "test-jwt-secret"
+
+// signToken builds a compact JWT with the given header and claims, signed with HS256
+// under secret whatever alg the header names
+func signToken(t *testing.T, secret string, header, claims map[string]any) string {
+	t.Helper()
+	encode := func(v map[string]any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signingInput := encode(header) + "." + encode(claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// userToken is a valid HS256 token for role expiring at exp
+func userToken(t *testing.T, role string, exp time.Time) string {
+	return signToken(t, testSecret,
+		map[string]any{"alg": "HS256", "typ": "JWT"},
+		map[string]any{"sub": "user-1", "role": role, "exp": exp.Unix()})
+}
+
+func TestVerifyHS256(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	hs256 := map[string]any{"alg": "HS256", "typ": "JWT"}
+	claims := func(extra map[string]any) map[string]any {
+		c := map[string]any{"sub": "user-1", "role": "authenticated", "exp": now.Add(time.Hour).Unix()}
+		for k, v := range extra {
+			c[k] = v
+		}
+		return c
+	}
+	valid := signToken(t, testSecret, hs256, claims(nil))
+	parts := strings.Split(valid, ".")
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr string
+	}{
+		{"valid", valid, ""},
+		{"wrong secret", signToken(t, "other-secret", hs256, claims(nil)), "invalid token signature"},
+		{"tampered claims", parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"role":"service_role","exp":9999999999}`)) + "." + parts[2], "invalid token signature"},
+		{"stripped signature", parts[0] + "." + parts[1] + ".", "invalid token signature"},
+		{"alg none", signToken(t, testSecret, map[string]any{"alg": "none"}, claims(nil)), "unsupported token algorithm"},
+		{"alg HS512", signToken(t, testSecret, map[string]any{"alg": "HS512"}, claims(nil)), "unsupported token algorithm"},
+		{"alg RS256 signed with the secret", signToken(t, testSecret, map[string]any{"alg": "RS256"}, claims(nil)), "unsupported token algorithm"},
+		{"expired", signToken(t, testSecret, hs256, claims(map[string]any{"exp": now.Add(-time.Minute).Unix()})), "token has expired"},
+		{"expired within leeway", signToken(t, testSecret, hs256, claims(map[string]any{"exp": now.Add(-10 * time.Second).Unix()})), ""},
+		{"no expiry", signToken(t, testSecret, hs256, map[string]any{"sub": "user-1", "role": "authenticated"}), "token has no expiry"},
+		{"not valid yet", signToken(t, testSecret, hs256, claims(map[string]any{"nbf": now.Add(time.Minute).Unix()})), "token is not valid yet"},
+		{"nbf within leeway", signToken(t, testSecret, hs256, claims(map[string]any{"nbf": now.Add(10 * time.Second).Unix()})), ""},
+		{"two parts", parts[0] + "." + parts[1], "malformed token"},
+		{"bad header encoding", "!!!." + parts[1] + "." + parts[2], "malformed token header"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifyHS256(tt.token, testSecret, now)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got.Subject != "user-1" || got.Role != "authenticated" {
+					t.Errorf("claims = %+v", got)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthenticateRoles(t *testing.T) {
+	now := time.Now()
+	opts := AuthOptions{
+		JWTSecret:    testSecret,
+		AllowedRoles: []string{"authenticated", "service_role"},
+		APIKeys:      []string{"staff-key"},
+	}
+
+	tests := []struct {
+		name              string
+		token             string
+		wantAuthenticated bool
+		wantMethod        string
+		wantErr           bool
+	}{
+		{"authenticated user", userToken(t, "authenticated", now.Add(time.Hour)), true, "jwt", false},
+		{"service role", userToken(t, "service_role", now.Add(time.Hour)), true, "jwt", false},
+		{"anon key", userToken(t, "anon", now.Add(time.Hour)), false, "jwt", false},
+		{"no role", signToken(t, testSecret, map[string]any{"alg": "HS256"}, map[string]any{"exp": now.Add(time.Hour).Unix()}), false, "jwt", false},
+		{"api key", "staff-key", true, "api_key", false},
+		{"unknown api key", "other-key", false, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := authenticate(tt.token, opts, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if principal.Authenticated != tt.wantAuthenticated || principal.Method != tt.wantMethod {
+				t.Errorf("principal = %+v, want authenticated %v via %q", principal, tt.wantAuthenticated, tt.wantMethod)
+			}
+		})
+	}
+
+	// Each API key is a separate caller, identified without the key itself
+	first, _ := authenticate("staff-key", AuthOptions{APIKeys: []string{"staff-key", "print-key"}}, now)
+	second, _ := authenticate("print-key", AuthOptions{APIKeys: []string{"staff-key", "print-key"}}, now)
+	if first.ID() == second.ID() || strings.Contains(first.ID(), "staff-key") {
+		t.Errorf("API key IDs = %q and %q, want distinct fingerprints", first.ID(), second.ID())
+	}
+	if id := (Principal{Method: "jwt", Subject: "user-1"}).ID(); id != "" {
+		t.Errorf("unauthenticated principal ID = %q, want empty", id)
+	}
+
+	// Without a JWT secret configured, only API keys are accepted
+	if _, err := authenticate(userToken(t, "authenticated", now.Add(time.Hour)), AuthOptions{APIKeys: []string{"staff-key"}}, now); err == nil {
+		t.Error("JWT accepted with no secret configured")
+	}
+}
+
+func TestAuthMiddlewareModes(t *testing.T) {
+	now := time.Now()
+	credentials := map[string]func(r *http.Request){
+		"none":        func(r *http.Request) {},
+		"api key":     func(r *http.Request) { r.Header.Set("X-API-Key", "staff-key") },
+		"bearer key":  func(r *http.Request) { r.Header.Set("Authorization", "Bearer staff-key") },
+		"unknown key": func(r *http.Request) { r.Header.Set("X-API-Key", "other-key") },
+		"user jwt": func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer "+userToken(t, "authenticated", now.Add(time.Hour)))
+		},
+		"anon jwt": func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer "+userToken(t, "anon", now.Add(time.Hour)))
+		},
+		"expired jwt": func(r *http.Request) {
+			r.Header.Set("Authorization", "bearer "+userToken(t, "authenticated", now.Add(-time.Hour)))
+		},
+		"basic scheme": func(r *http.Request) { r.Header.Set("Authorization", "Basic c3RhZmY6a2V5") },
+		"forged jwt": func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer "+signToken(t, "guess", map[string]any{"alg": "HS256"}, map[string]any{"role": "service_role", "exp": now.Add(time.Hour).Unix()}))
+		},
+		"alg none jwt": func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer "+signToken(t, "", map[string]any{"alg": "none"}, map[string]any{"role": "service_role", "exp": now.Add(time.Hour).Unix()}))
+		},
+		"malformed jwt": func(r *http.Request) { r.Header.Set("Authorization", "Bearer not.a.jwt") },
+	}
+
+	// Each case is the status, and for 200 whether the handler saw an authenticated caller
+	tests := []struct {
+		mode              string
+		credential        string
+		wantStatus        int
+		wantAuthenticated bool
+	}{
+		{AuthModeRedact, "none", http.StatusOK, false},
+		{AuthModeRedact, "basic scheme", http.StatusOK, false},
+		{AuthModeRedact, "anon jwt", http.StatusOK, false},
+		{AuthModeRedact, "api key", http.StatusOK, true},
+		{AuthModeRedact, "bearer key", http.StatusOK, true},
+		{AuthModeRedact, "user jwt", http.StatusOK, true},
+		{AuthModeRedact, "unknown key", http.StatusUnauthorized, false},
+		{AuthModeRedact, "expired jwt", http.StatusUnauthorized, false},
+		{AuthModeRedact, "forged jwt", http.StatusUnauthorized, false},
+		{AuthModeRedact, "alg none jwt", http.StatusUnauthorized, false},
+		{AuthModeRedact, "malformed jwt", http.StatusUnauthorized, false},
+
+		{AuthModeRequired, "none", http.StatusUnauthorized, false},
+		{AuthModeRequired, "basic scheme", http.StatusUnauthorized, false},
+		{AuthModeRequired, "anon jwt", http.StatusUnauthorized, false},
+		{AuthModeRequired, "api key", http.StatusOK, true},
+		{AuthModeRequired, "bearer key", http.StatusOK, true},
+		{AuthModeRequired, "user jwt", http.StatusOK, true},
+		{AuthModeRequired, "unknown key", http.StatusUnauthorized, false},
+		{AuthModeRequired, "expired jwt", http.StatusUnauthorized, false},
+		{AuthModeRequired, "forged jwt", http.StatusUnauthorized, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode+"/"+tt.credential, func(t *testing.T) {
+			auth := AuthMiddleware(zap.NewNop(), AuthOptions{
+				Mode:         tt.mode,
+				JWTSecret:    testSecret,
+				AllowedRoles: []string{"authenticated", "service_role"},
+				APIKeys:      []string{"staff-key"},
+			})
+			handler := auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(strconv.FormatBool(IsAuthenticated(r.Context()))))
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/event-pdf/AB1", nil)
+			credentials[tt.credential](req)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus == http.StatusUnauthorized {
+				if rec.Header().Get("WWW-Authenticate") == "" {
+					t.Error("401 without a WWW-Authenticate challenge")
+				}
+				if got := rec.Header().Get("Content-Type"); got != ProblemContentType {
+					t.Errorf("Content-Type = %q, want %q", got, ProblemContentType)
+				}
+				return
+			}
+			if got := rec.Body.String(); got != strconv.FormatBool(tt.wantAuthenticated) {
+				t.Errorf("handler saw authenticated = %s, want %v", got, tt.wantAuthenticated)
+			}
+		})
+	}
+}
@@ -13,6 +13,8 @@ func CORSMiddleware() *cors.Cors {
 		AllowedMethods: []string{
 			http.MethodGet,
 			http.MethodHead,
+			http.MethodPost,
+			http.MethodDelete,
 			http.MethodOptions,
 		},
 		AllowedHeaders: []string{
@@ -20,13 +22,17 @@ func CORSMiddleware() *cors.Cors {
 			"Authorization",
 			"Content-Type",
 			"X-CSRF-Token",
+			"X-API-Key",
+			RequestIDHeader,
 		},
 		ExposedHeaders: []string{
 			"Content-Length",
 			"Content-Type",
 			"Content-Disposition",
+			"Location",
+			RequestIDHeader,
 		},
 		AllowCredentials: false,
 		MaxAge:           300, // 5 minutes
 	})
-}
\ No newline at end of file
+}
@@ -30,6 +30,7 @@ func LoggingMiddleware(logger *zap.Logger) func(http.Handler) http.Handler {
 			// Log the request
 			duration := time.Since(start)
 			logger.Info("HTTP request",
+				zap.String("request_id", RequestIDFromContext(r.Context())),
 				zap.String("method", r.Method),
 				zap.String("path", r.URL.Path),
 				zap.String("remote_addr", r.RemoteAddr),
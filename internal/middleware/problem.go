@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType is the media type of error responses (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body. Code, EID and Section are extension members.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"` // The request ID
+
+	Code    string `json:"code"`              // Machine-readable, the last part of Type
+	EID     string `json:"eid,omitempty"`     // The event the request was for
+	Section string `json:"section,omitempty"` // The section or document that failed to render
+
+	// Error repeats Detail for clients written before problem+json. It will be removed
+	// once they have moved to detail and code.
+	Error string `json:"error"`
+}
+
+// ProblemType returns the type URI for a problem code
+func ProblemType(code string) string {
+	return "urn:paperwork-service:problem:" + code
+}
+
+// WriteProblem sends p as problem+json. Type, Title, Instance and Error are filled in from
+// Code, Status, the request ID and Detail when left empty.
+func WriteProblem(w http.ResponseWriter, r *http.Request, p Problem) error {
+	if p.Type == "" {
+		p.Type = ProblemType(p.Code)
+	}
+	if p.Title == "" {
+		p.Title = http.StatusText(p.Status)
+	}
+	if p.Instance == "" {
+		p.Instance = RequestIDFromContext(r.Context())
+	}
+	if p.Error == "" {
+		p.Error = p.Detail
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
+}
@@ -32,6 +32,9 @@ type Event struct {
 	Currency             string    `json:"currency"`
 	CreatedAt            time.Time `json:"created_at"`
 	UpdatedAt            time.Time `json:"updated_at"`
+
+	// Optional paperwork labels for rounds, e.g. {"3": "Final Round"}
+	RoundLabels map[int]string `json:"round_labels,omitempty"`
 }
 
 // EventWithDetails includes related data for paperwork generation
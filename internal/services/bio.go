@@ -0,0 +1,218 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"paperwork-service/internal/models"
+)
+
+// Bio sources an artist's printed bio can come from
+const (
+	// BioSourceBio is the bio the artist entered for the event
+	BioSourceBio = "bio"
+	// BioSourceABHQ is the bio kept by Art Battle HQ
+	BioSourceABHQ = "abhq"
+)
+
+// BioPolicy decides which bio is printed for an artist
+type BioPolicy struct {
+	// Prefer is the source tried first (BioSourceBio or BioSourceABHQ); the other is the fallback
+	Prefer string
+	// HistoryFallback generates a line from the artist's event history when both bios are empty
+	HistoryFallback bool
+}
+
+// DefaultBioPolicy prefers the event bio, then the ABHQ bio, then event history
+var DefaultBioPolicy = BioPolicy{Prefer: BioSourceBio, HistoryFallback: true}
+
+// resolveBio returns the sanitized bio to print for the artist, or "" if there is none
+func (p BioPolicy) resolveBio(artist models.EventArtist) string {
+	sources := []string{artist.Bio, artist.ABHQBio}
+	if p.Prefer == BioSourceABHQ {
+		sources = []string{artist.ABHQBio, artist.Bio}
+	}
+
+	for _, source := range sources {
+		if bio := sanitizeBio(source); bio != "" {
+			return bio
+		}
+	}
+
+	if p.HistoryFallback {
+		return historyBio(artist.EventHistory)
+	}
+	return ""
+}
+
+// applyBios returns a copy of the artists with Bio replaced by the policy's choice
+func (p BioPolicy) applyBios(artists []models.EventArtist) []models.EventArtist {
+	resolved := append([]models.EventArtist(nil), artists...)
+	for i := range resolved {
+		resolved[i].Bio = p.resolveBio(resolved[i])
+	}
+	return resolved
+}
+
+// historyBio describes an artist from their Art Battle event history,
+// e.g. "Art Battle artist since 2019 with 7 events and 2 wins."
+func historyBio(history []models.ArtistEvent) string {
+	if len(history) == 0 {
+		return ""
+	}
+
+	firstYear := 0
+	wins := 0
+	for _, event := range history {
+		if event.IsWinner {
+			wins++
+		}
+		if year := event.EventDate.Year(); !event.EventDate.IsZero() && (firstYear == 0 || year < firstYear) {
+			firstYear = year
+		}
+	}
+
+	line := "Art Battle artist"
+	if firstYear > 0 {
+		line += fmt.Sprintf(" since %d", firstYear)
+	}
+	line += " with " + plural(len(history), "event", "events")
+	if wins > 0 {
+		line += " and " + plural(wins, "win", "wins")
+	}
+	return line + "."
+}
+
+// plural formats a count with the singular or plural noun
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, pluralForm)
+}
+
+var (
+	htmlParagraphPattern = regexp.MustCompile(`(?i)</p\s*>|</div\s*>`)
+	htmlBreakPattern     = regexp.MustCompile(`(?i)<br\s*/?>|</li\s*>`)
+	htmlBoldPattern      = regexp.MustCompile(`(?i)</?(?:b|strong)\s*>`)
+	htmlItalicPattern    = regexp.MustCompile(`(?i)</?(?:i|em)\s*>`)
+	htmlLinkPattern      = regexp.MustCompile(`(?is)<a\s[^>]*?href\s*=\s*["']([^"']+)["'][^>]*>(.*?)</a\s*>`)
+	htmlTagPattern       = regexp.MustCompile(`<[^>]*>`)
+	markdownImagePattern = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownLinePattern  = regexp.MustCompile(`(?m)^[ \t]*(?:#{1,6}[ \t]+|>[ \t]?|[-*+][ \t]+|\d+\.[ \t]+)`)
+	markdownCodePattern  = regexp.MustCompile("`([^`]+)`|~~(.+?)~~")
+	linkOrURLPattern     = regexp.MustCompile(`\[[^\]]+\]\([^)\s]+\)|(?i)\b(?:https?://|www\.)[^\s<>()\[\]]+`)
+	spacePattern         = regexp.MustCompile(`[ \t\f\v\x{00A0}]+`)
+	blankLinesPattern    = regexp.MustCompile(`\n{3,}`)
+)
+
+// socialHosts print as @handle rather than as a domain
+var socialHosts = map[string]bool{
+	"instagram.com": true,
+	"twitter.com":   true,
+	"x.com":         true,
+	"tiktok.com":    true,
+	"facebook.com":  true,
+	"threads.net":   true,
+}
+
+// sanitizeBio reduces a bio to the light Markdown subset the PDF renders: paragraphs,
+// line breaks, **bold**, *italic* and [links](url). HTML is converted to that subset,
+// other Markdown syntax and emoji are removed, bare URLs become links labelled with
+// a handle or bare domain, and whitespace is collapsed.
+func sanitizeBio(bio string) string {
+	bio = strings.ReplaceAll(bio, "\r\n", "\n")
+
+	// HTML: keep paragraphs, breaks, emphasis and links; drop every other tag
+	bio = htmlParagraphPattern.ReplaceAllString(bio, "\n\n")
+	bio = htmlBreakPattern.ReplaceAllString(bio, "\n")
+	bio = htmlBoldPattern.ReplaceAllString(bio, "**")
+	bio = htmlItalicPattern.ReplaceAllString(bio, "*")
+	bio = htmlLinkPattern.ReplaceAllString(bio, "[$2]($1)")
+	bio = htmlTagPattern.ReplaceAllString(bio, "")
+	bio = html.UnescapeString(bio)
+
+	// Markdown outside the subset: images, headings, lists, quotes, code and strikethrough
+	bio = markdownImagePattern.ReplaceAllString(bio, "$1")
+	bio = markdownLinePattern.ReplaceAllString(bio, "")
+	bio = markdownCodePattern.ReplaceAllString(bio, "$1$2")
+
+	// Bare URLs print as a short label but stay clickable; existing links are kept
+	bio = linkOrURLPattern.ReplaceAllStringFunc(bio, func(match string) string {
+		if strings.HasPrefix(match, "[") {
+			return match
+		}
+		return urlToLink(match)
+	})
+
+	bio = strings.Map(func(r rune) rune {
+		if isEmoji(r) {
+			return -1
+		}
+		return r
+	}, bio)
+
+	// Collapse whitespace, keeping line breaks and single blank lines between paragraphs
+	lines := strings.Split(bio, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spacePattern.ReplaceAllString(line, " "))
+	}
+	bio = blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+
+	return strings.TrimSpace(bio)
+}
+
+// urlToLink turns a bare URL into a Markdown link labelled with its handle or domain,
+// e.g. https://instagram.com/jane_doe_art becomes [@jane_doe_art](https://instagram.com/jane_doe_art)
+func urlToLink(raw string) string {
+	link := strings.TrimRight(raw, ".,;:!?")
+	label := urlToHandle(link)
+	if label == link {
+		return raw
+	}
+	return "[" + label + "](" + link + ")" + raw[len(link):]
+}
+
+// urlToHandle labels a social profile URL as @handle and any other URL as its bare domain
+func urlToHandle(raw string) string {
+	link := raw
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	host = strings.TrimPrefix(host, "m.")
+	if socialHosts[host] {
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if handle := strings.TrimPrefix(segments[0], "@"); handle != "" {
+			return "@" + handle
+		}
+	}
+	return host
+}
+
+// isEmoji reports whether r is an emoji or an emoji modifier
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // Emoticons, pictographs, flags, transport
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // Miscellaneous symbols and dingbats
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // Arrows and stars used as emoji
+		return true
+	case r >= 0xFE00 && r <= 0xFE0F: // Variation selectors
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // Tag characters
+		return true
+	case r == 0x200D || r == 0x20E3: // Zero width joiner, keycap
+		return true
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"paperwork-service/internal/models"
+)
+
+func TestSanitizeBio(t *testing.T) {
+	tests := []struct {
+		name string
+		bio  string
+		want string
+	}{
+		{"plain text", "Paints big.", "Paints big."},
+		{"whitespace", "  Paints \t big  murals.  ", "Paints big murals."},
+		{"line endings and blank lines", "One.\r\nTwo.\n\n\n\nThree.", "One.\nTwo.\n\nThree."},
+		{"html paragraphs and breaks", "<p>One.</p><p>Two<br/>lines.</p>", "One.\n\nTwo\nlines."},
+		{"html emphasis", "<b>Bold</b>, <em>italic</em> and <span class=\"x\">plain</span>", "**Bold**, *italic* and plain"},
+		{"html link", `See <a href="https://example.com/work" target="_blank">my work</a>.`, "See [my work](https://example.com/work)."},
+		{"html entities", "Fish &amp; chips &lt;3", "Fish & chips <3"},
+		{"markdown headings, lists and quotes", "# About\n- oils\n* acrylics\n1. ink\n> quoted", "About\noils\nacrylics\nink\nquoted"},
+		{"markdown image, code and strikethrough", "![me](me.png) `code` ~~old~~", "me code old"},
+		{"markdown subset kept", "**bold** *italic* [site](https://example.com)", "**bold** *italic* [site](https://example.com)"},
+		{"instagram url", "Follow https://instagram.com/jane_doe_art/ now", "Follow [@jane_doe_art](https://instagram.com/jane_doe_art/) now"},
+		{"instagram at handle", "www.instagram.com/@jane.", "[@jane](www.instagram.com/@jane)."},
+		{"mobile social url", "https://m.facebook.com/janepaints", "[@janepaints](https://m.facebook.com/janepaints)"},
+		{"other url", "Shop: https://www.janedoe.ca/shop?x=1", "Shop: [janedoe.ca](https://www.janedoe.ca/shop?x=1)"},
+		{"social url without handle", "https://x.com/", "[x.com](https://x.com/)"},
+		{"url inside a link", "[my site](https://janedoe.ca)", "[my site](https://janedoe.ca)"},
+		{"emoji", "Painter 🎨✨ from Toronto 🇨🇦", "Painter from Toronto"},
+		{"emoji with joiner", "Hi 👩‍🎨!", "Hi !"},
+		{"only markup", "<p> </p>🎨", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeBio(tt.bio); got != tt.want {
+				t.Errorf("sanitizeBio(%q) = %q, want %q", tt.bio, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveBio(t *testing.T) {
+	history := []models.ArtistEvent{
+		{EventDate: time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC), IsWinner: true},
+		{EventDate: time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{},
+	}
+
+	tests := []struct {
+		name   string
+		policy BioPolicy
+		artist models.EventArtist
+		want   string
+	}{
+		{"event bio first", DefaultBioPolicy, models.EventArtist{Bio: "Event bio.", ABHQBio: "HQ bio."}, "Event bio."},
+		{"abhq first", BioPolicy{Prefer: BioSourceABHQ}, models.EventArtist{Bio: "Event bio.", ABHQBio: "HQ bio."}, "HQ bio."},
+		{"fallback after sanitizing", DefaultBioPolicy, models.EventArtist{Bio: "<p>🎨</p>", ABHQBio: "HQ bio."}, "HQ bio."},
+		{"history", DefaultBioPolicy, models.EventArtist{EventHistory: history}, "Art Battle artist since 2019 with 3 events and 1 win."},
+		{"history without dates or wins", DefaultBioPolicy, models.EventArtist{EventHistory: []models.ArtistEvent{{}}}, "Art Battle artist with 1 event."},
+		{"history disabled", BioPolicy{Prefer: BioSourceBio}, models.EventArtist{EventHistory: history}, ""},
+		{"nothing", DefaultBioPolicy, models.EventArtist{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.resolveBio(tt.artist); got != tt.want {
+				t.Errorf("resolveBio = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
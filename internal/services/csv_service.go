@@ -0,0 +1,108 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"paperwork-service/internal/models"
+)
+
+// utf8BOM lets Excel detect UTF-8 when opening a CSV file directly
+const utf8BOM = "\ufeff"
+
+// CSVOptions controls CSV export formatting and redaction
+type CSVOptions struct {
+	// Profile controls redaction; empty is treated as public
+	Profile Profile
+	// BOM prefixes the output with a UTF-8 byte order mark for Excel
+	BOM bool
+}
+
+// WriteRosterCSV writes the artist list rows as CSV. Round-Easel is plain text such as
+// 1-4, which Excel reads as a date when it opens the file directly; the numeric Round and
+// Easel columns, or the XLSX export, avoid that.
+func WriteRosterCSV(w io.Writer, artists []models.EventArtist, opts CSVOptions) error {
+	profile := opts.Profile
+	if profile == "" {
+		profile = ProfilePublic
+	}
+	artists = redactArtists(artists, profile)
+
+	records := [][]string{{"Round-Easel", "Round", "Easel", "Artist Name"}}
+	for _, row := range BuildRosterRows(artists) {
+		records = append(records, []string{
+			row.RoundEasel,
+			strconv.Itoa(row.RoundNumber),
+			strconv.Itoa(row.EaselNumber),
+			csvText(row.ArtistName),
+		})
+	}
+
+	return writeCSV(w, records, opts.BOM)
+}
+
+// WriteAuctionCSV writes the auction table rows as CSV, with the top bid as a plain number.
+// The bidder and payment columns are left out for profiles that don't show bidders.
+func WriteAuctionCSV(w io.Writer, event *models.Event, artists []models.EventArtist, auctionLots []models.AuctionLot, opts CSVOptions) error {
+	profile := opts.Profile
+	if profile == "" {
+		profile = ProfilePublic
+	}
+	artists = redactArtists(artists, profile)
+	auctionLots = redactLots(auctionLots, profile)
+	showBidders := profile.ShowsBidders()
+
+	header := []string{"EID-Round-Easel", "Artist Name", "Bids", "Top Bid", "Currency"}
+	if showBidders {
+		header = append(header, "Bidder", "Payment Status")
+	}
+
+	records := [][]string{header}
+	for _, row := range BuildAuctionRows(event.EID, artists, auctionLots) {
+		topBid := ""
+		if row.TopBid > 0 {
+			topBid = strconv.FormatFloat(row.TopBid, 'f', 2, 64)
+		}
+
+		record := []string{
+			row.ID,
+			csvText(row.ArtistName),
+			strconv.Itoa(row.BidCount),
+			topBid,
+			event.Currency,
+		}
+		if showBidders {
+			record = append(record, csvText(row.Bidder), csvText(row.PaymentStatus))
+		}
+		records = append(records, record)
+	}
+
+	return writeCSV(w, records, opts.BOM)
+}
+
+// csvText neutralizes free-text cells that a spreadsheet would otherwise run as a formula
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// writeCSV writes records with RFC 4180 quoting and an optional BOM
+func writeCSV(w io.Writer, records [][]string, bom bool) error {
+	if bom {
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = true
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
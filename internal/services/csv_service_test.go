@@ -0,0 +1,127 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+
+	"paperwork-service/internal/models"
+)
+
+// readCSV parses CSV output written without a BOM
+func readCSV(t *testing.T, out string) [][]string {
+	t.Helper()
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\n%s", err, out)
+	}
+	return records
+}
+
+func TestCSVText(t *testing.T) {
+	tests := map[string]string{
+		"":                        "",
+		"Jane Doe":                "Jane Doe",
+		"=HYPERLINK(\"x\",\"y\")": "'=HYPERLINK(\"x\",\"y\")",
+		"+1 555 0100":             "'+1 555 0100",
+		"-2+3":                    "'-2+3",
+		"@SUM(A1:A2)":             "'@SUM(A1:A2)",
+		"\tcmd":                   "'\tcmd",
+		"\rcmd":                   "'\rcmd",
+		"O'Neil = artist":         "O'Neil = artist",
+	}
+	for in, want := range tests {
+		if got := csvText(in); got != want {
+			t.Errorf("csvText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWriteRosterCSV(t *testing.T) {
+	artists := []models.EventArtist{
+		{RoundNumber: 1, EaselNumber: 4, ArtistName: "=cmd|' /C calc'!A0"},
+		{RoundNumber: 2, EaselNumber: 12, ArtistName: `Mary "Mo" Smith, Jr.`},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRosterCSV(&buf, artists, CSVOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	if strings.HasPrefix(out, utf8BOM) {
+		t.Error("BOM written without the BOM option")
+	}
+	if strings.Count(out, "\r\n") != 3 || strings.Count(out, "\n") != 3 {
+		t.Errorf("rows are not CRLF terminated: %q", out)
+	}
+
+	// Round-Easel is plain text, not an Excel formula, so every CSV reader sees 1-4
+	if !strings.Contains(out, "\r\n1-4,1,4,") {
+		t.Errorf("Round-Easel is not written as plain text: %q", out)
+	}
+
+	want := [][]string{
+		{"Round-Easel", "Round", "Easel", "Artist Name"},
+		{"1-4", "1", "4", "'=cmd|' /C calc'!A0"},
+		{"2-12", "2", "12", `Mary "Mo" Smith, Jr.`},
+	}
+	if got := readCSV(t, out); !reflect.DeepEqual(got, want) {
+		t.Errorf("records = %q, want %q", got, want)
+	}
+}
+
+func TestWriteCSVWithBOM(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteRosterCSV(&buf, nil, CSVOptions{BOM: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "\ufeffRound-Easel,Round,Easel,Artist Name\r\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte{0xEF, 0xBB, 0xBF}) {
+		t.Error("BOM is not the UTF-8 byte sequence EF BB BF")
+	}
+}
+
+func TestWriteAuctionCSV(t *testing.T) {
+	event := &models.Event{EID: "AB4000", Currency: "CAD"}
+	artists := []models.EventArtist{
+		{RoundNumber: 1, EaselNumber: 1, ArtistName: "Alma Artist"},
+		{RoundNumber: 1, EaselNumber: 2, ArtistName: "Bo Brush"},
+		{RoundNumber: 1, EaselNumber: 3, ArtistName: "Stand By", Status: "confirmed-only"},
+	}
+	lots := []models.AuctionLot{{
+		Round: 1, EaselNumber: 1, BidCount: 3, HighestBid: 1250.5,
+		WinningBid: &models.Bid{BidderName: "+Wendy Winner", PaymentStatus: "paid"},
+	}}
+
+	tests := []struct {
+		profile Profile
+		want    [][]string
+	}{
+		{ProfileStaff, [][]string{
+			{"EID-Round-Easel", "Artist Name", "Bids", "Top Bid", "Currency", "Bidder", "Payment Status"},
+			{"AB4000-1-1", "Alma Artist", "3", "1250.50", "CAD", "'+Wendy Winner", "paid"},
+			{"AB4000-1-2", "Bo Brush", "0", "", "CAD", "", ""},
+		}},
+		{ProfilePublic, [][]string{
+			{"EID-Round-Easel", "Artist Name", "Bids", "Top Bid", "Currency"},
+			{"AB4000-1-1", "Alma Artist", "3", "1250.50", "CAD"},
+			{"AB4000-1-2", "Bo Brush", "0", "", "CAD"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.profile), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteAuctionCSV(&buf, event, artists, lots, CSVOptions{Profile: tt.profile}); err != nil {
+				t.Fatal(err)
+			}
+			if got := readCSV(t, buf.String()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("records = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
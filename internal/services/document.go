@@ -0,0 +1,389 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Document block types
+const (
+	BlockText      = "text"
+	BlockMultiline = "multiline"
+	BlockTable     = "table"
+	BlockQR        = "qr"
+	BlockImage     = "image"
+)
+
+// ErrUnknownDocument is returned when no document with the requested name was loaded
+var ErrUnknownDocument = errors.New("unknown document")
+
+// Document is a declarative paperwork document, read from a JSON file in
+// templates/pdf/documents. New document types can be added without writing Go.
+type Document struct {
+	Name  string         `json:"-"` // The file name without .json
+	Pages []DocumentPage `json:"pages"`
+}
+
+// DocumentPage is one page, or one page per item of a list when Repeat is set.
+// Tables and flowing text that run out of room continue on a copy of the page.
+type DocumentPage struct {
+	// Background is an image in the backgrounds directory (optional)
+	Background string `json:"background,omitempty"`
+	// Repeat adds the page once per item of a list, e.g. "ReadyArtists" or "BioGroups"
+	Repeat string `json:"repeat,omitempty"`
+	// When and Unless name a true/false field that decides whether the page is drawn
+	When   string `json:"when,omitempty"`
+	Unless string `json:"unless,omitempty"`
+	// Title is a text block repeated with "(continued)" on continuation pages (optional)
+	Title  *DocumentBlock  `json:"title,omitempty"`
+	Blocks []DocumentBlock `json:"blocks"`
+}
+
+// DocumentBlock is one element of a page. Every block has a position and size; the other
+// fields depend on the block type.
+type DocumentBlock struct {
+	Type   string  `json:"type"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+
+	// Text, multiline and plain table blocks: font, size and color, as for a layout field
+	Font        string      `json:"font,omitempty"`
+	FontSize    float64     `json:"fontSize,omitempty"`
+	Color       LayoutColor `json:"color"`
+	MinFontSize float64     `json:"minFontSize,omitempty"`
+	LineHeight  float64     `json:"lineHeight,omitempty"`
+	// Align is L, C or R within the block's width (optional, L by default)
+	Align string `json:"align,omitempty"`
+
+	// Bind is a field path such as "Artist.Name". Text and multiline blocks use either Bind or
+	// Text, a text/template such as "Round {{.Artist.RoundNumber}}" run against DocumentData.
+	Bind   string `json:"bind,omitempty"`
+	Text   string `json:"text,omitempty"`
+	When   string `json:"when,omitempty"`
+	Unless string `json:"unless,omitempty"`
+	// FontStep is how far text shrinks per step towards MinFontSize (default 2pt for text, 0.5pt for multiline)
+	FontStep float64 `json:"fontStep,omitempty"`
+
+	// Multiline: Empty is printed when the bound text is empty. Flowing text continues onto
+	// new pages and, with Repeat, prints a Heading and text per item.
+	Empty     string         `json:"empty,omitempty"`
+	Flow      bool           `json:"flow,omitempty"`
+	Repeat    string         `json:"repeat,omitempty"`
+	Heading   *DocumentBlock `json:"heading,omitempty"`
+	KeepLines int            `json:"keepLines,omitempty"`
+	Gap       float64        `json:"gap,omitempty"`
+
+	// Table: Rows is the list to print, one row per item. Plain tables have no header or
+	// borders and stop after MaxItems rows, printing More ("%d" is the number left out).
+	Rows      string           `json:"rows,omitempty"`
+	RowHeight float64          `json:"rowHeight,omitempty"`
+	Columns   []DocumentColumn `json:"columns,omitempty"`
+	Plain     bool             `json:"plain,omitempty"`
+	MaxItems  int              `json:"maxItems,omitempty"`
+	More      string           `json:"more,omitempty"`
+
+	// Image: Src is a file in the backgrounds directory
+	Src string `json:"src,omitempty"`
+
+	tmpl *template.Template // Text, parsed by Validate
+}
+
+// field is the block's box, font and alignment as a layout field, for the checks and
+// drawing code shared with the layout config
+func (b *DocumentBlock) field() LayoutField {
+	return LayoutField{
+		X: b.X, Y: b.Y, Width: b.Width, Height: b.Height,
+		Font: b.Font, FontSize: b.FontSize, Color: b.Color,
+		MinFontSize: b.MinFontSize, LineHeight: b.LineHeight, Align: b.Align,
+	}
+}
+
+// DocumentColumn is a table column bound to a field of the row items, or a template run
+// against each row item, e.g. "{{currency .Amount}}"
+type DocumentColumn struct {
+	LayoutColumn
+	Bind string `json:"bind,omitempty"`
+	Text string `json:"text,omitempty"`
+
+	tmpl *template.Template // Text, parsed by Validate
+}
+
+// LoadDocuments reads every *.json document in dir, keyed by file name without the extension,
+// and validates them. Problems in every file are reported together.
+func LoadDocuments(dir string) (map[string]*Document, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("failed to read documents: %w", err)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list documents: %w", err)
+	}
+
+	documents := make(map[string]*Document)
+	var errs []error
+	for _, path := range paths {
+		doc, err := loadDocument(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		documents[doc.Name] = doc
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return documents, nil
+}
+
+// loadDocument reads and validates one document file
+func loadDocument(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document: %w", err)
+	}
+
+	doc := &Document{Name: strings.TrimSuffix(filepath.Base(path), ".json")}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+
+	if err := doc.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return doc, nil
+}
+
+// documentNames lists the loaded document names in order, for error messages
+func documentNames(documents map[string]*Document) []string {
+	names := make([]string, 0, len(documents))
+	for name := range documents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks every page and block: positions on the page, known fonts and that
+// every field path exists in DocumentData with a type the block can print. Text templates
+// are parsed here, once, and kept on the document for rendering.
+func (d *Document) Validate() error {
+	v := &layoutValidator{}
+	if len(d.Pages) == 0 {
+		v.fail("pages", "a document needs at least one page")
+	}
+
+	dataType := reflect.TypeOf(DocumentData{})
+	for p := range d.Pages {
+		page := &d.Pages[p]
+		path := fmt.Sprintf("pages[%d]", p)
+		if page.Repeat != "" {
+			v.repeatPath(path+".repeat", dataType, page.Repeat)
+		}
+		v.boolPath(path+".when", dataType, page.When)
+		v.boolPath(path+".unless", dataType, page.Unless)
+
+		if page.Title != nil {
+			v.block(path+".title", dataType, page.Title, BlockText)
+		}
+		for b := range page.Blocks {
+			block := &page.Blocks[b]
+			v.block(fmt.Sprintf("%s.blocks[%d]", path, b), dataType, block, block.Type)
+		}
+	}
+	return errors.Join(v.errs...)
+}
+
+// block checks one block as the given type; titles and headings are always text
+func (v *layoutValidator) block(path string, dataType reflect.Type, b *DocumentBlock, kind string) {
+	v.boolPath(path+".when", dataType, b.When)
+	v.boolPath(path+".unless", dataType, b.Unless)
+	switch kind {
+	case BlockText:
+		v.text(path, b.field())
+		if (b.Text == "") == (b.Bind == "") {
+			v.fail(path, "needs either text or bind")
+		}
+		if b.Bind != "" {
+			v.scalarPath(path+".bind", dataType, b.Bind)
+		}
+		b.tmpl = v.template(path+".text", dataType, b.Text)
+
+	case BlockMultiline:
+		v.text(path, b.field())
+		if b.LineHeight <= 0 {
+			v.fail(path, "lineHeight must be positive")
+		}
+		if (b.Text == "") == (b.Bind == "") {
+			v.fail(path, "needs either text or bind")
+		}
+		if b.Bind != "" {
+			v.stringPath(path+".bind", dataType, b.Bind)
+		}
+		if !b.Flow && b.Height <= 0 {
+			v.fail(path, "height must be positive unless the text flows")
+		}
+		if b.Repeat != "" {
+			if !b.Flow {
+				v.fail(path, "repeat needs flow")
+			}
+			v.repeatPath(path+".repeat", dataType, b.Repeat)
+		}
+		b.tmpl = v.template(path+".text", dataType, b.Text)
+		if b.Heading != nil {
+			v.block(path+".heading", dataType, b.Heading, BlockText)
+		}
+
+	case BlockTable:
+		v.tableBlock(path, dataType, b)
+
+	case BlockQR:
+		v.image(path, b.field())
+		if b.Bind == "" {
+			v.fail(path, "needs bind")
+		} else {
+			v.stringPath(path+".bind", dataType, b.Bind)
+		}
+
+	case BlockImage:
+		v.image(path, b.field())
+		if b.Src == "" {
+			v.fail(path, "needs src")
+		}
+
+	default:
+		v.fail(path, "unknown block type %q (use text, multiline, table, qr or image)", kind)
+	}
+}
+
+// tableBlock checks a table block, binding its columns against the row items
+func (v *layoutValidator) tableBlock(path string, dataType reflect.Type, b *DocumentBlock) {
+	v.box(path, b.field())
+	if b.RowHeight <= 0 {
+		v.fail(path, "rowHeight must be positive")
+	}
+	if b.Plain {
+		v.text(path, b.field())
+	} else if b.FontSize <= 0 {
+		v.fail(path, "fontSize must be positive")
+	}
+	if len(b.Columns) == 0 {
+		v.fail(path, "needs at least one column")
+	}
+
+	if b.Rows == "" {
+		v.fail(path, "needs rows")
+		return
+	}
+	rowsType, err := fieldPathType(dataType, b.Rows)
+	if err != nil {
+		v.fail(path+".rows", "%v", err)
+		return
+	}
+	if rowsType.Kind() != reflect.Slice {
+		v.fail(path+".rows", "%s is not a list", b.Rows)
+		return
+	}
+
+	width := 0.0
+	for i := range b.Columns {
+		column := &b.Columns[i]
+		columnPath := fmt.Sprintf("%s.columns[%d]", path, i)
+		if column.Width <= 0 {
+			v.fail(columnPath, "width must be positive")
+		}
+		if column.Align != "L" && column.Align != "C" && column.Align != "R" {
+			v.fail(columnPath, "align must be L, C or R")
+		}
+		if (column.Text == "") == (column.Bind == "") {
+			v.fail(columnPath, "needs either text or bind")
+		}
+		if column.Bind != "" {
+			v.scalarPath(columnPath+".bind", rowsType.Elem(), column.Bind)
+		}
+		column.tmpl = v.template(columnPath+".text", rowsType.Elem(), column.Text)
+		width += column.Width
+	}
+	if b.X+width > pageWidth {
+		v.fail(path, "x + column widths (%.1f) is past the page width %.1f", b.X+width, pageWidth)
+	}
+}
+
+// repeatPath checks a path names a list of artists, lots or bio groups, which repeats can step through
+func (v *layoutValidator) repeatPath(path string, dataType reflect.Type, fieldPath string) {
+	t, err := fieldPathType(dataType, fieldPath)
+	if err != nil {
+		v.fail(path, "%v", err)
+		return
+	}
+	if t.Kind() != reflect.Slice {
+		v.fail(path, "%s is not a list of artists, lots or bio groups", fieldPath)
+		return
+	}
+	switch t.Elem() {
+	case reflect.TypeOf(DocArtist{}), reflect.TypeOf(DocAuctionRow{}), reflect.TypeOf(DocBioGroup{}):
+	default:
+		v.fail(path, "%s is not a list of artists, lots or bio groups", fieldPath)
+	}
+}
+
+// template parses an optional text template. Its errors already name the field path.
+func (v *layoutValidator) template(path string, dataType reflect.Type, text string) *template.Template {
+	if text == "" {
+		return nil
+	}
+	tmpl, err := parseTextTemplate(path, text, dataType)
+	if err != nil {
+		v.errs = append(v.errs, err)
+		return nil
+	}
+	return tmpl
+}
+
+// boolPath checks an optional path names a true/false field
+func (v *layoutValidator) boolPath(path string, dataType reflect.Type, fieldPath string) {
+	if fieldPath == "" {
+		return
+	}
+	t, err := fieldPathType(dataType, fieldPath)
+	if err != nil {
+		v.fail(path, "%v", err)
+	} else if t.Kind() != reflect.Bool {
+		v.fail(path, "%s is not true/false", fieldPath)
+	}
+}
+
+// stringPath checks a path names a text field
+func (v *layoutValidator) stringPath(path string, dataType reflect.Type, fieldPath string) {
+	t, err := fieldPathType(dataType, fieldPath)
+	if err != nil {
+		v.fail(path, "%v", err)
+	} else if t.Kind() != reflect.String {
+		v.fail(path, "%s is not text", fieldPath)
+	}
+}
+
+// scalarPath checks a path names a field that prints as a single value
+func (v *layoutValidator) scalarPath(path string, dataType reflect.Type, fieldPath string) {
+	t, err := fieldPathType(dataType, fieldPath)
+	if err != nil {
+		v.fail(path, "%v", err)
+		return
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64:
+	default:
+		v.fail(path, "%s cannot be printed", fieldPath)
+	}
+}
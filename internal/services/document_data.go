@@ -0,0 +1,240 @@
+package services
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"paperwork-service/internal/models"
+)
+
+// DocumentData is what document blocks bind to, by field path such as "Event.Name".
+// Repeats set Artist or Round to the current item, e.g. "Artist.Bio" on a page per artist.
+type DocumentData struct {
+	Event *models.Event
+	// ShowBidders is true when the profile allows bidder and payment details
+	ShowBidders bool
+
+	Roster  []RosterRow
+	Auction []DocAuctionRow
+	// Artists are every artist in paperwork order; ReadyArtists leave out confirmed-only artists
+	Artists      []DocArtist
+	ReadyArtists []DocArtist
+	BioGroups    []DocBioGroup
+
+	// Set while repeating over artists, lots or bio groups
+	Artist *DocArtist
+	Lot    *DocAuctionRow
+	Round  *DocBioGroup
+}
+
+// DocArtist is an artist with the values the artist pages print
+type DocArtist struct {
+	models.EventArtist
+	Name       string // Display name, falling back to artist name and then first and last name
+	RoundEasel string // e.g. "Round 1 - Easel 4"
+	QRURL      string // Instagram profile, or the event page when there is none
+	History    []DocHistory
+}
+
+// DocHistory is one past event of an artist
+type DocHistory struct {
+	models.ArtistEvent
+	Summary string // e.g. "AB3001 R1-E4 W", W marking a win
+}
+
+// DocAuctionRow is an auction table row formatted for printing, "-" where there is no value.
+// Amount is the unformatted top bid, for templates such as "{{currency .Lot.Amount}}".
+type DocAuctionRow struct {
+	ID            string
+	RoundNumber   int
+	EaselNumber   int
+	ArtistName    string
+	BidCount      int
+	TopBid        string
+	Amount        float64 // 0 when there are no bids
+	Bidder        string
+	PaymentStatus string
+}
+
+// DocBioGroup is one bio summary group, e.g. the artists of round 1
+type DocBioGroup struct {
+	Title   string // e.g. "Round 1 Artist Bios"
+	Heading string // The page title, e.g. "Toronto Finals - Round 1 Artist Bios"
+	Artists []DocArtist
+}
+
+// buildDocumentData prepares the data documents bind to. Artists and lots must already be
+// redacted for the profile and have their bios resolved.
+func buildDocumentData(event *models.Event, artists []models.EventArtist, auctionLots []models.AuctionLot, showBidders bool) *DocumentData {
+	data := &DocumentData{
+		Event:        event,
+		ShowBidders:  showBidders,
+		Roster:       BuildRosterRows(artists),
+		Auction:      []DocAuctionRow{},
+		Artists:      docArtists(event.EID, artists),
+		ReadyArtists: []DocArtist{},
+		BioGroups:    []DocBioGroup{},
+	}
+
+	for _, artist := range data.Artists {
+		if artist.Status != "confirmed-only" {
+			data.ReadyArtists = append(data.ReadyArtists, artist)
+		}
+	}
+
+	for _, row := range BuildAuctionRows(event.EID, artists, auctionLots) {
+		printed := DocAuctionRow{
+			ID:            row.ID,
+			RoundNumber:   row.RoundNumber,
+			EaselNumber:   row.EaselNumber,
+			ArtistName:    row.ArtistName,
+			BidCount:      row.BidCount,
+			TopBid:        "-",
+			Amount:        row.TopBid,
+			Bidder:        "-",
+			PaymentStatus: "-",
+		}
+		if row.TopBid > 0 {
+			printed.TopBid = fmt.Sprintf("%s%.0f", currencySymbol(event.Currency), row.TopBid)
+		}
+		if row.Bidder != "" || row.PaymentStatus != "" {
+			printed.Bidder = row.Bidder
+			printed.PaymentStatus = row.PaymentStatus
+		}
+		data.Auction = append(data.Auction, printed)
+	}
+
+	for _, group := range groupBios(artists, event.RoundLabels) {
+		data.BioGroups = append(data.BioGroups, DocBioGroup{
+			Title:   group.Title,
+			Heading: fmt.Sprintf("%s - %s", event.Name, group.Title),
+			Artists: docArtists(event.EID, group.Artists),
+		})
+	}
+
+	return data
+}
+
+// docArtists adds the printed values to each artist
+func docArtists(eventEID string, artists []models.EventArtist) []DocArtist {
+	out := make([]DocArtist, 0, len(artists))
+	for _, artist := range artists {
+		doc := DocArtist{
+			EventArtist: artist,
+			Name:        resolveArtistName(artist),
+			RoundEasel:  fmt.Sprintf("Round %d - Easel %d", artist.RoundNumber, artist.EaselNumber),
+			QRURL:       artistQRURL(eventEID, artist),
+			History:     make([]DocHistory, 0, len(artist.EventHistory)),
+		}
+		for _, event := range artist.EventHistory {
+			doc.History = append(doc.History, DocHistory{ArtistEvent: event, Summary: historySummary(event)})
+		}
+		out = append(out, doc)
+	}
+	return out
+}
+
+// withItem returns a copy of the data scoped to one repeat item
+func (d *DocumentData) withItem(item reflect.Value) *DocumentData {
+	scoped := *d
+	switch value := item.Addr().Interface().(type) {
+	case *DocArtist:
+		scoped.Artist = value
+	case *DocAuctionRow:
+		scoped.Lot = value
+	case *DocBioGroup:
+		scoped.Round = value
+	}
+	return &scoped
+}
+
+// fieldPathType follows a dotted field path through struct types, as validation does
+func fieldPathType(t reflect.Type, path string) (reflect.Type, error) {
+	for _, name := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%s: %s has no fields", path, t)
+		}
+		field, ok := t.FieldByName(name)
+		if !ok || !field.IsExported() {
+			return nil, fmt.Errorf("%s: unknown field %q", path, name)
+		}
+		t = field.Type
+	}
+	return t, nil
+}
+
+// fieldPathValue follows a dotted field path through values. A nil pointer on the way, such
+// as Artist outside an artist repeat, gives an invalid Value.
+func fieldPathValue(v reflect.Value, path string) reflect.Value {
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.FieldByName(name)
+		if !v.IsValid() {
+			return v
+		}
+	}
+	return v
+}
+
+// bindText returns the printed text of a field path; missing values print as ""
+func (d *DocumentData) bindText(path string) string {
+	return formatValue(fieldPathValue(reflect.ValueOf(d), path))
+}
+
+// bindBool returns a true/false field; an empty path is true
+func (d *DocumentData) bindBool(path string) bool {
+	if path == "" {
+		return true
+	}
+	v := fieldPathValue(reflect.ValueOf(d), path)
+	return v.IsValid() && v.Bool()
+}
+
+// visible reports whether a page or block with these when/unless fields is drawn
+func (d *DocumentData) visible(when, unless string) bool {
+	return d.bindBool(when) && (unless == "" || !d.bindBool(unless))
+}
+
+// bindList returns the items of a list field
+func (d *DocumentData) bindList(path string) []reflect.Value {
+	v := fieldPathValue(reflect.ValueOf(d), path)
+	if !v.IsValid() {
+		return nil
+	}
+	items := make([]reflect.Value, v.Len())
+	for i := range items {
+		items[i] = v.Index(i)
+	}
+	return items
+}
+
+// formatValue prints a bound value
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		if v.Bool() {
+			return "Yes"
+		}
+		return "No"
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,453 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"paperwork-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+	"go.uber.org/zap"
+)
+
+// GenerateDocument renders a declarative document by name. Redaction and the bio policy
+// apply exactly as for the built-in sections; Sections in opts is ignored.
+func (s *PaperworkPDFService) GenerateDocument(ctx context.Context, name string, event *models.Event, artists []models.EventArtist, auctionLots []models.AuctionLot, opts RenderOptions) ([]byte, error) {
+	doc, ok := s.documents[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDocument, name)
+	}
+
+	profile := opts.Profile
+	if profile == "" {
+		profile = ProfilePublic
+	}
+	artists = s.bioPolicy.applyBios(redactArtists(artists, profile))
+	auctionLots = redactLots(auctionLots, profile)
+	data := buildDocumentData(event, artists, auctionLots, profile.ShowsBidders())
+
+	r := &documentRenderer{
+		service:   s,
+		pdf:       s.newDocument(),
+		doc:       doc,
+		budget:    opts.BioBudget,
+		templates: newTextTemplates(event),
+	}
+	if opts.Debug {
+		r.debug = newDebugOverlay(r.pdf)
+	}
+
+	for p, page := range doc.Pages {
+		scopes := []*DocumentData{data}
+		if page.Repeat != "" {
+			scopes = scopes[:0]
+			for _, item := range data.bindList(page.Repeat) {
+				scopes = append(scopes, data.withItem(item))
+			}
+		}
+
+		for _, scope := range scopes {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if scope.visible(page.When, page.Unless) {
+				r.page(fmt.Sprintf("pages[%d]", p), page, scope)
+			}
+		}
+	}
+
+	// A document can legitimately produce no pages (e.g. one page per artist with no artists)
+	if r.pdf.PageNo() == 0 {
+		r.pdf.AddPage()
+	}
+
+	return s.outputDocument(r.pdf, Section(name))
+}
+
+// HasDocument reports whether a document with the given name was loaded
+func (s *PaperworkPDFService) HasDocument(name string) bool {
+	_, ok := s.documents[name]
+	return ok
+}
+
+// DocumentNames lists the loaded documents in alphabetical order
+func (s *PaperworkPDFService) DocumentNames() []string {
+	return documentNames(s.documents)
+}
+
+// documentRenderer draws one document into a PDF
+type documentRenderer struct {
+	service   *PaperworkPDFService
+	pdf       *gofpdf.Fpdf
+	doc       *Document
+	budget    BioBudget
+	templates *textTemplates
+	debug     *debugOverlay
+}
+
+// page draws a page and its blocks. Blocks that run out of room continue on copies of the page.
+func (r *documentRenderer) page(path string, page DocumentPage, data *DocumentData) {
+	// The block being drawn, for the debug overlay of continuation pages
+	var blockPath string
+	var current DocumentBlock
+
+	r.startPage(path, page, data, false)
+	continuePage := func() {
+		r.startPage(path, page, data, true)
+		r.guide(blockPath, current)
+	}
+
+	for i, block := range page.Blocks {
+		if !data.visible(block.When, block.Unless) {
+			continue
+		}
+		blockPath = fmt.Sprintf("%s.blocks[%d]", path, i)
+		current = block
+		r.guide(blockPath, block)
+
+		switch block.Type {
+		case BlockText:
+			r.text(block, r.blockText(blockPath, block, data))
+		case BlockMultiline:
+			if block.Flow {
+				r.flow(blockPath, block, data, continuePage)
+			} else {
+				r.multiline(blockPath, block, data)
+			}
+		case BlockTable:
+			r.table(blockPath, block, data, continuePage)
+		case BlockQR:
+			if url := data.bindText(block.Bind); url != "" {
+				drawQRCode(r.pdf, url, "qr_"+url, block.field())
+			}
+		case BlockImage:
+			r.image(block)
+		}
+	}
+}
+
+// startPage adds the page with its background and title; continuation pages mark the title
+func (r *documentRenderer) startPage(path string, page DocumentPage, data *DocumentData, continued bool) {
+	if page.Background == "" {
+		r.pdf.AddPage()
+	} else {
+		r.service.addPageWithBackground(r.pdf, page.Background)
+	}
+
+	if page.Title != nil && data.visible(page.Title.When, page.Title.Unless) {
+		title := r.blockText(path+".title", *page.Title, data)
+		if continued {
+			title += " (continued)"
+		}
+		r.text(*page.Title, title)
+		r.debug.field(path+".title", page.Title.field())
+	}
+}
+
+// guide records a block's box and baselines for the debug overlay
+func (r *documentRenderer) guide(path string, b DocumentBlock) {
+	if r.debug == nil {
+		return
+	}
+	label := path + " " + b.Type
+
+	switch b.Type {
+	case BlockText, BlockQR, BlockImage:
+		r.debug.field(label, b.field())
+
+	case BlockMultiline:
+		box := b.field()
+		if b.Flow {
+			// Flowing text fills the rest of the page
+			if box.Width == 0 {
+				_, _, rightMargin, _ := r.pdf.GetMargins()
+				box.Width = pageWidth - rightMargin - box.X
+			}
+			box.Height = tableBottom - box.Y
+		}
+		r.debug.lines(label, box, b.LineHeight)
+
+	case BlockTable:
+		widths := make([]float64, len(b.Columns))
+		for i, column := range b.Columns {
+			widths[i] = column.Width
+		}
+		rows := 2 // Header and first row
+		if b.Plain {
+			rows = b.MaxItems + 1
+		}
+		r.debug.table(label, b.X, b.Y, widths, b.RowHeight, b.FontSize, rows)
+	}
+}
+
+// blockText is a block's text for a single line
+func (r *documentRenderer) blockText(path string, b DocumentBlock, data *DocumentData) string {
+	return cleanString(r.blockValue(path, b, data))
+}
+
+// blockValue is a block's bound value or executed text template
+func (r *documentRenderer) blockValue(path string, b DocumentBlock, data *DocumentData) string {
+	if b.tmpl == nil {
+		return data.bindText(b.Bind)
+	}
+	return r.execute(path+".text", b.tmpl, data)
+}
+
+// execute runs a text template; a failure is logged and prints nothing, so one bad value
+// does not lose the whole document
+func (r *documentRenderer) execute(path string, tmpl *template.Template, data interface{}) string {
+	text, err := r.templates.execute(tmpl, data)
+	if err != nil {
+		r.service.logger.Warn("Document text template failed",
+			zap.String("document", r.doc.Name),
+			zap.String("field", path),
+			zap.Error(err))
+		return ""
+	}
+	return text
+}
+
+// text draws a single line, shrinking it towards MinFontSize until it fits the width
+func (r *documentRenderer) text(b DocumentBlock, text string) {
+	size := b.FontSize
+	if b.MinFontSize > 0 && b.Width > 0 {
+		step := b.FontStep
+		if step <= 0 {
+			step = 2
+		}
+		r.pdf.SetFont(b.Font, "", size)
+		for r.pdf.GetStringWidth(text) > b.Width && size > b.MinFontSize && size > step {
+			size -= step
+			r.pdf.SetFont(b.Font, "", size)
+		}
+	}
+
+	b.field().setText(r.pdf, size)
+	r.pdf.SetXY(b.X, b.Y)
+	r.pdf.CellFormat(b.Width, b.Height, text, "", 0, blockAlign(b.Align), false, 0, "")
+}
+
+// multiline fits text into the block's box, shrinking and then truncating it as needed
+func (r *documentRenderer) multiline(path string, b DocumentBlock, data *DocumentData) {
+	text := r.blockValue(path, b, data)
+	if text == "" {
+		if b.Empty != "" {
+			b.field().setText(r.pdf, b.FontSize)
+			r.pdf.SetXY(b.X, b.Y)
+			r.pdf.Cell(b.Width, b.LineHeight, cleanString(b.Empty))
+		}
+		return
+	}
+
+	box := textBox{
+		X:           b.X,
+		Y:           b.Y,
+		Width:       b.Width,
+		Height:      b.Height,
+		Font:        b.Font,
+		MaxFontSize: b.FontSize,
+		MinFontSize: b.MinFontSize,
+		FontStep:    b.FontStep,
+		LineSpacing: b.LineHeight / (b.FontSize * ptToMM), // lineHeight is given at the full font size
+	}
+	if box.MinFontSize == 0 {
+		box.MinFontSize = box.MaxFontSize
+	}
+	if box.FontStep <= 0 {
+		box.FontStep = 0.5
+	}
+
+	r.pdf.SetTextColor(b.Color.R, b.Color.G, b.Color.B)
+	fit := fitText(r.pdf, parseMarkdown(text), box)
+	box.draw(r.pdf, fit)
+
+	if fit.Truncated {
+		fields := []zap.Field{
+			zap.String("document", r.doc.Name),
+			zap.String("block", path),
+			zap.String("eid", data.Event.EID),
+		}
+		if data.Artist != nil {
+			fields = append(fields,
+				zap.Int("entry_id", data.Artist.EntryID),
+				zap.String("artist", data.Artist.Name))
+		}
+		r.service.logger.Warn("Document text truncated to fit its box", fields...)
+	}
+}
+
+// flow prints text down the page from the block's position, once per repeat item with its
+// heading, continuing onto new pages. The heading is drawn at the block's X and is never
+// left at the bottom of a page without KeepLines lines of its text.
+func (r *documentRenderer) flow(path string, b DocumentBlock, data *DocumentData, continuePage func()) {
+	pdf := r.pdf
+
+	items := []*DocumentData{data}
+	if b.Repeat != "" {
+		items = items[:0]
+		for _, item := range data.bindList(b.Repeat) {
+			items = append(items, data.withItem(item))
+		}
+	}
+
+	// Zero width runs to the right margin, as MultiCell(0, ...) does
+	width := b.Width
+	if width == 0 {
+		_, _, rightMargin, _ := pdf.GetMargins()
+		width = pageWidth - rightMargin - b.X
+	}
+
+	newPage := func() {
+		continuePage()
+		pdf.SetXY(b.X, b.Y)
+	}
+
+	pdf.SetXY(b.X, b.Y)
+	for _, item := range items {
+		text := r.blockValue(path, b, item)
+		if text == "" {
+			text = b.Empty
+		}
+		layout := bioLayout(pdf, text, b.Font, b.FontSize, width, b.LineHeight, r.budget)
+
+		heading := b.Heading
+		if heading != nil && !item.visible(heading.When, heading.Unless) {
+			heading = nil
+		}
+
+		keepHeight := 0.0
+		if heading != nil {
+			keepHeight = heading.Height
+		}
+		for i, line := range layout.Lines {
+			if i == b.KeepLines {
+				break
+			}
+			keepHeight += line.Gap + b.LineHeight
+		}
+		if pdf.GetY()+keepHeight > tableBottom {
+			newPage()
+		}
+
+		if heading != nil {
+			heading.field().setText(pdf, heading.FontSize)
+			pdf.SetX(b.X)
+			pdf.Cell(heading.Width, heading.Height, r.blockText(path+".heading", *heading, item))
+			pdf.Ln(heading.Height)
+		}
+
+		// Draw a line at a time so the text can continue on the next page
+		pdf.SetTextColor(b.Color.R, b.Color.G, b.Color.B)
+		for _, line := range layout.Lines {
+			y := pdf.GetY() + line.Gap
+			if y+b.LineHeight > tableBottom {
+				newPage()
+				pdf.SetTextColor(b.Color.R, b.Color.G, b.Color.B)
+				y = pdf.GetY()
+			}
+			drawRichLine(pdf, layout, line, b.X, y)
+			pdf.SetXY(b.X, y+b.LineHeight)
+		}
+		pdf.Ln(b.Gap)
+	}
+}
+
+// table prints one row per item of the bound list. Bordered tables repeat their header
+// on continuation pages; plain tables stop after MaxItems rows.
+func (r *documentRenderer) table(path string, b DocumentBlock, data *DocumentData, continuePage func()) {
+	pdf := r.pdf
+	rows := data.bindList(b.Rows)
+
+	cells := func(row reflect.Value) []string {
+		values := make([]string, len(b.Columns))
+		for i, column := range b.Columns {
+			if column.tmpl != nil {
+				values[i] = cleanString(r.execute(fmt.Sprintf("%s.columns[%d].text", path, i), column.tmpl, row.Interface()))
+			} else {
+				values[i] = cleanString(formatValue(fieldPathValue(row, column.Bind)))
+			}
+		}
+		return values
+	}
+
+	headers := make([]string, len(b.Columns))
+	widths := make([]float64, len(b.Columns))
+	aligns := make([]string, len(b.Columns))
+	for i, column := range b.Columns {
+		headers[i] = column.Header
+		widths[i] = column.Width
+		aligns[i] = column.Align
+	}
+
+	if b.Plain {
+		b.field().setText(pdf, b.FontSize)
+		for i, row := range rows {
+			pdf.SetXY(b.X, b.Y+float64(i)*b.RowHeight)
+			if b.MaxItems > 0 && i >= b.MaxItems {
+				if b.More != "" {
+					more := strings.ReplaceAll(b.More, "%d", strconv.Itoa(len(rows)-b.MaxItems))
+					pdf.Cell(sumWidths(widths), b.RowHeight, cleanString(more))
+				}
+				break
+			}
+			for j, cell := range cells(row) {
+				pdf.CellFormat(widths[j], b.RowHeight, cell, "", 0, aligns[j], false, 0, "")
+			}
+		}
+		return
+	}
+
+	pdf.SetXY(b.X, b.Y)
+	table := &pdfTable{
+		pdf:       pdf,
+		left:      b.X,
+		headers:   headers,
+		colWidths: widths,
+		rowHeight: b.RowHeight,
+		fontSize:  b.FontSize,
+		continuePage: func() {
+			continuePage()
+			pdf.SetXY(b.X, b.Y)
+		},
+	}
+	table.header()
+	for _, row := range rows {
+		table.row(cells(row), aligns, false)
+	}
+}
+
+// image places a picture from the backgrounds directory; a missing file is skipped
+func (r *documentRenderer) image(b DocumentBlock) {
+	path := filepath.Join(r.service.backgroundsPath, b.Src)
+	if _, err := os.Stat(path); err != nil {
+		r.service.logger.Warn("Document image not found",
+			zap.String("document", r.doc.Name),
+			zap.String("src", b.Src),
+			zap.Error(err))
+		return
+	}
+	r.pdf.ImageOptions(path, b.X, b.Y, b.Width, b.Height, false, gofpdf.ImageOptions{ReadDpi: true}, 0, "")
+}
+
+// blockAlign defaults a block's alignment to left
+func blockAlign(align string) string {
+	if align == "" {
+		return "L"
+	}
+	return align
+}
+
+// sumWidths is the total width of a table's columns
+func sumWidths(widths []float64) float64 {
+	total := 0.0
+	for _, width := range widths {
+		total += width
+	}
+	return total
+}
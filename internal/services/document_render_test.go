@@ -0,0 +1,130 @@
+package services
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"go.uber.org/zap"
+)
+
+// pdfPageStreamPattern finds each page object and the content stream gofpdf writes right after it
+var pdfPageStreamPattern = regexp.MustCompile(`(?s)/Type /Page[^s].*?stream\r?\n(.*?)\r?\nendstream`)
+
+// pdfPages returns the inflated drawing operators of each page, in page order
+func pdfPages(t *testing.T, pdf []byte) [][]byte {
+	t.Helper()
+	var pages [][]byte
+	for _, match := range pdfPageStreamPattern.FindAllSubmatch(pdf, -1) {
+		r, err := zlib.NewReader(bytes.NewReader(match[1]))
+		if err != nil {
+			t.Fatalf("page %d: %v", len(pages)+1, err)
+		}
+		page, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("page %d: %v", len(pages)+1, err)
+		}
+		pages = append(pages, page)
+	}
+	if len(pages) == 0 {
+		t.Fatal("PDF has no pages")
+	}
+	return pages
+}
+
+// firstDiff returns a short excerpt of both pages from the first byte that differs
+func firstDiff(a, b []byte) (string, string) {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	start := max(0, i-40)
+	return string(a[start:min(len(a), i+80)]), string(b[start:min(len(b), i+80)])
+}
+
+// The built-in sections and the reference documents in templates/pdf/documents are two
+// implementations of the same pages. This fails as soon as one changes without the other.
+func TestDocumentsMatchBuiltInSections(t *testing.T) {
+	gofpdf.SetDefaultCreationDate(time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC))
+	defer gofpdf.SetDefaultCreationDate(time.Time{})
+
+	documents, err := LoadDocuments("../../templates/pdf/documents")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewPaperworkPDFService(zap.NewNop(), "../../templates", DefaultBioPolicy, DefaultLayout(), documents)
+	event, artists, lots := SampleEvent()
+
+	sections := map[string]Section{
+		"artist-list":  SectionArtistList,
+		"auction":      SectionAuction,
+		"bios":         SectionBios,
+		"artist-pages": SectionArtistPages,
+	}
+	if len(documents) != len(sections) {
+		t.Errorf("%d reference documents, want %d: add a parity case for new ones", len(documents), len(sections))
+	}
+
+	for name, section := range sections {
+		for _, profile := range []Profile{ProfileStaff, ProfilePublic} {
+			t.Run(name+"/"+string(profile), func(t *testing.T) {
+				builtIn, err := s.GenerateEventPaperworkContext(context.Background(), event, artists, lots, RenderOptions{
+					Sections: []Section{section},
+					Profile:  profile,
+				})
+				if err != nil {
+					t.Fatalf("section: %v", err)
+				}
+				document, err := s.GenerateDocument(context.Background(), name, event, artists, lots, RenderOptions{Profile: profile})
+				if err != nil {
+					t.Fatalf("document: %v", err)
+				}
+
+				want, got := pdfPages(t, builtIn), pdfPages(t, document)
+				if len(got) != len(want) {
+					t.Fatalf("document has %d pages, section has %d", len(got), len(want))
+				}
+				for i := range want {
+					if !bytes.Equal(got[i], want[i]) {
+						section, document := firstDiff(want[i], got[i])
+						t.Errorf("page %d differs\nsection:  %q\ndocument: %q", i+1, section, document)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestDocumentBlockKeepsAlignAndColumns(t *testing.T) {
+	dir := writeDocument(t, "blocks.json", `{"pages": [{"blocks": [
+		{"type": "text", "x": 20, "y": 20, "width": 100, "height": 10, "font": "AcuminBold", "fontSize": 10, "align": "R", "bind": "Event.Name"},
+		{"type": "table", "x": 20, "y": 40, "rowHeight": 8, "fontSize": 9, "rows": "Auction",
+			"columns": [{"width": 40, "align": "C", "bind": "ID"}, {"width": 60, "align": "L", "bind": "ArtistName"}]}
+	]}]}`)
+	documents, err := LoadDocuments(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks := documents["blocks"].Pages[0].Blocks
+	// The debug overlay and shared drawing code see the block through field()
+	if got := blocks[0].field(); got.Align != "R" || got.Width != 100 || got.Font != "AcuminBold" {
+		t.Errorf("text block field = %+v", got)
+	}
+	if len(blocks[1].Columns) != 2 || blocks[1].Columns[0].Align != "C" {
+		t.Errorf("table columns = %+v", blocks[1].Columns)
+	}
+
+	// Layout field keys that mean nothing on a block are rejected
+	dir = writeDocument(t, "gap.json", `{"pages": [{"blocks": [
+		{"type": "text", "x": 20, "y": 20, "font": "AcuminBold", "fontSize": 10, "columnGap": 5, "bind": "Event.Name"}
+	]}]}`)
+	if _, err := LoadDocuments(dir); err == nil || !strings.Contains(err.Error(), `unknown field "columnGap"`) {
+		t.Errorf("err = %v, want columnGap rejected", err)
+	}
+}
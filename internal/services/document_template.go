@@ -0,0 +1,216 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"paperwork-service/internal/models"
+
+	"go.uber.org/zap"
+)
+
+// templateFuncs is the function set available in document text templates. Currency and dates
+// follow the event being rendered; parsing uses a nil event, which only checks the names.
+//
+//	currency AMOUNT        - amount with the event's currency symbol, e.g. $150 or $152.50
+//	date LAYOUT TIME       - time in the event's time zone, in Go layout form, e.g. "Jan 2 3:04 PM"
+//	upper TEXT, lower TEXT - change case
+//	truncate N TEXT        - at most N characters, ending with "..." when cut
+//	default FALLBACK VALUE - FALLBACK when VALUE is empty or zero
+func templateFuncs(event *models.Event) template.FuncMap {
+	return template.FuncMap{
+		"currency": func(amount interface{}) (string, error) {
+			value, ok := toFloat(amount)
+			if !ok {
+				return "", fmt.Errorf("%v is not a number", amount)
+			}
+			code := ""
+			if event != nil {
+				code = event.Currency
+			}
+			return formatAmount(currencySymbol(code), value), nil
+		},
+		"date": func(layout string, t time.Time) string {
+			if t.IsZero() {
+				return ""
+			}
+			if event != nil {
+				t = t.In(eventLocation(event))
+			}
+			return t.Format(layout)
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"truncate": func(n int, text string) string {
+			runes := []rune(text)
+			if n < 0 || len(runes) <= n {
+				return text
+			}
+			return strings.TrimSpace(string(runes[:n])) + "..."
+		},
+		"default": func(fallback interface{}, value interface{}) interface{} {
+			if value == nil || reflect.ValueOf(value).IsZero() {
+				return fallback
+			}
+			return value
+		},
+	}
+}
+
+// templateFuncNames lists the template functions, for error messages
+func templateFuncNames() string {
+	names := make([]string, 0, len(templateFuncs(nil)))
+	for name := range templateFuncs(nil) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// toFloat converts a template number to float64
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	}
+	return 0, false
+}
+
+// parseTextTemplate parses a text field as a template named by its field path, and checks
+// the fields it reads from dot exist on dataType
+func parseTextTemplate(path string, text string, dataType reflect.Type) (*template.Template, error) {
+	tmpl, err := template.New(path).Funcs(templateFuncs(nil)).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTemplateFields(tmpl.Tree.Root, dataType, true); err != nil {
+		return nil, fmt.Errorf("template: %s: %w", path, err)
+	}
+	return tmpl, nil
+}
+
+// checkTemplateFields checks field references such as {{.Artist.Name}} and {{$.Event.Name}}
+// against dataType, and that every function called is one of templateFuncs rather than a
+// text/template builtin such as call or printf. Inside range and with, dot is something
+// else, so only $ is checked there.
+func checkTemplateFields(node parse.Node, dataType reflect.Type, dotIsRoot bool) error {
+	check := func(fields []string) error {
+		_, err := fieldPathType(dataType, strings.Join(fields, "."))
+		return err
+	}
+
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkTemplateFields(child, dataType, dotIsRoot); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return checkTemplateFields(n.Pipe, dataType, dotIsRoot)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			for _, arg := range cmd.Args {
+				if err := checkTemplateFields(arg, dataType, dotIsRoot); err != nil {
+					return err
+				}
+			}
+		}
+	case *parse.IdentifierNode:
+		if _, ok := templateFuncs(nil)[n.Ident]; !ok {
+			return fmt.Errorf("%s is not a template function (use one of %s)", n.Ident, templateFuncNames())
+		}
+	case *parse.FieldNode:
+		if dotIsRoot {
+			return check(n.Ident)
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			return check(n.Ident[1:])
+		}
+	case *parse.IfNode:
+		return checkBranch(&n.BranchNode, dataType, dotIsRoot, dotIsRoot)
+	case *parse.RangeNode:
+		return checkBranch(&n.BranchNode, dataType, dotIsRoot, false)
+	case *parse.WithNode:
+		return checkBranch(&n.BranchNode, dataType, dotIsRoot, false)
+	}
+	return nil
+}
+
+// checkBranch checks an if, range or with: its pipeline sees the outer dot, its body may not
+func checkBranch(n *parse.BranchNode, dataType reflect.Type, dotIsRoot bool, bodyDotIsRoot bool) error {
+	if err := checkTemplateFields(n.Pipe, dataType, dotIsRoot); err != nil {
+		return err
+	}
+	if err := checkTemplateFields(n.List, dataType, bodyDotIsRoot); err != nil {
+		return err
+	}
+	return checkTemplateFields(n.ElseList, dataType, dotIsRoot)
+}
+
+// textTemplates executes document text templates for one render, with currency and dates
+// bound to the event. Bound copies are made once per template.
+type textTemplates struct {
+	funcs template.FuncMap
+	bound map[*template.Template]*template.Template
+}
+
+// newTextTemplates prepares templates for rendering one event
+func newTextTemplates(event *models.Event) *textTemplates {
+	return &textTemplates{
+		funcs: templateFuncs(event),
+		bound: make(map[*template.Template]*template.Template),
+	}
+}
+
+// execute runs a parsed text template against data
+func (t *textTemplates) execute(tmpl *template.Template, data interface{}) (string, error) {
+	bound, ok := t.bound[tmpl]
+	if !ok {
+		clone, err := tmpl.Clone()
+		if err != nil {
+			return "", err
+		}
+		bound = clone.Funcs(t.funcs)
+		t.bound[tmpl] = bound
+	}
+
+	var buf bytes.Buffer
+	if err := bound.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// layoutText is a layout field's text template run against data, or fallback when the
+// field has none. A template that fails to run logs a warning and prints the fallback.
+func (s *PaperworkPDFService) layoutText(path string, field LayoutField, event *models.Event, data interface{}, fallback string) string {
+	if field.tmpl == nil {
+		return fallback
+	}
+	text, err := newTextTemplates(event).execute(field.tmpl, data)
+	if err != nil {
+		s.logger.Warn("Layout text template failed",
+			zap.String("field", path+".text"),
+			zap.String("eid", event.EID),
+			zap.Error(err))
+		return fallback
+	}
+	return cleanString(text)
+}
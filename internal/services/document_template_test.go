@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"paperwork-service/internal/models"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// writeDocument writes one document file to a temporary directory and returns the directory
+func writeDocument(t *testing.T, name string, document string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(document), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoadDocumentsTemplateErrors(t *testing.T) {
+	dir := writeDocument(t, "bad.json", `{"pages": [{
+		"title": {"x": 20, "y": 20, "width": 200, "height": 10, "font": "AcuminBold", "fontSize": 10, "text": "{{.Event.Nme}}"},
+		"blocks": [
+			{"type": "text", "x": 20, "y": 20, "font": "AcuminBold", "fontSize": 10, "text": "{{money .Event.Name}}"},
+			{"type": "text", "x": 20, "y": 20, "font": "AcuminBold", "fontSize": 10, "text": "{{.Event.Name"},
+			{"type": "text", "x": 20, "y": 20, "font": "AcuminBold", "fontSize": 10, "text": "{{range .Artists}}{{$.Artst}}{{end}}"},
+			{"type": "table", "x": 20, "y": 40, "rowHeight": 8, "fontSize": 9, "rows": "Auction",
+				"columns": [{"width": 40, "align": "C", "text": "{{currency .Amnt}}"}]}
+		]
+	}]}`)
+
+	_, err := LoadDocuments(dir)
+	if err == nil {
+		t.Fatal("document with bad templates loaded")
+	}
+	// Every problem is reported, each with the file and the field path
+	for _, want := range []string{
+		`bad.json: template: pages[0].title.text: Event.Nme: unknown field "Nme"`,
+		`template: pages[0].blocks[0].text:1: function "money" not defined`,
+		`template: pages[0].blocks[1].text:1: unclosed action`,
+		`template: pages[0].blocks[2].text: Artst: unknown field "Artst"`,
+		`template: pages[0].blocks[3].columns[0].text: Amnt: unknown field "Amnt"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not contain %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestTemplatesRejectFunctionsOutsideFuncMap(t *testing.T) {
+	dataType := reflect.TypeOf(DocumentData{})
+	// text/template's builtins are not part of the documented function set either
+	for _, text := range []string{
+		`{{printf "%s" .Event.Name}}`,
+		`{{call .Event.Name}}`,
+		`{{len .Artists}}`,
+		`{{index .Artists 0}}`,
+		`{{html .Event.Name}}`,
+		`{{if eq .Event.Name "x"}}x{{end}}`,
+		`{{.Event.Name | urlquery}}`,
+		`{{range .Artists}}{{print .Name}}{{end}}`,
+		`{{upper (slice .Event.Name 1)}}`,
+	} {
+		_, err := parseTextTemplate("pages[0].blocks[0].text", text, dataType)
+		if err == nil || !strings.Contains(err.Error(), "pages[0].blocks[0].text") || !strings.Contains(err.Error(), "is not a template function") {
+			t.Errorf("%s: err = %v, want it rejected with the field path", text, err)
+		}
+	}
+
+	// The layout config uses the same rules
+	path := writeLayout(t, `{"artistPage": {"fields": {"roundEasel": {"text": "{{printf \"%d\" .RoundNumber}}"}}}}`)
+	if _, err := LoadLayout(path); err == nil || !strings.Contains(err.Error(), "artistPage.fields.roundEasel.text: printf is not a template function") {
+		t.Errorf("LoadLayout err = %v", err)
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	event := &models.Event{Name: "Toronto Finals", Currency: "EUR", TimezoneIcann: "America/Toronto",
+		EventStartDatetime: time.Date(2026, 5, 1, 23, 30, 0, 0, time.UTC)}
+	data := &DocumentData{Event: event}
+
+	tests := map[string]string{
+		`{{currency 152.5}}`: "€152.50",
+		`{{currency 150}}`:   "€150",
+		`{{date "Jan 2 3:04 PM" .Event.EventStartDatetime}}`: "May 1 7:30 PM",
+		`{{upper .Event.Name}} {{lower .Event.Name}}`:        "TORONTO FINALS toronto finals",
+		`{{truncate 7 .Event.Name}}`:                         "Toronto...",
+		`{{.Event.Venue | default "TBA"}}`:                   "TBA",
+		`{{.Event.Name | default "TBA"}}`:                    "Toronto Finals",
+	}
+	templates := newTextTemplates(event)
+	for text, want := range tests {
+		tmpl, err := parseTextTemplate("text", text, reflect.TypeOf(DocumentData{}))
+		if err != nil {
+			t.Errorf("%s: %v", text, err)
+			continue
+		}
+		if got, err := templates.execute(tmpl, data); err != nil || got != want {
+			t.Errorf("%s = %q, %v, want %q", text, got, err, want)
+		}
+	}
+}
+
+func TestDocumentTemplateFailureNamesDocumentAndField(t *testing.T) {
+	// Parses, as the argument type is only known when the template runs
+	dir := writeDocument(t, "names.json", `{"pages": [{"blocks": [
+		{"type": "text", "x": 20, "y": 20, "width": 100, "height": 10, "font": "AcuminBold", "fontSize": 10, "text": "{{currency .Event.Name}}"}
+	]}]}`)
+	documents, err := LoadDocuments(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	core, logs := observer.New(zap.WarnLevel)
+	s := NewPaperworkPDFService(zap.New(core), "../../templates", DefaultBioPolicy, DefaultLayout(), documents)
+	event := &models.Event{EID: "AB4000", Name: "Big Night"}
+	if _, err := s.GenerateDocument(context.Background(), "names", event, nil, nil, RenderOptions{}); err != nil {
+		t.Fatalf("one failing template stopped the document: %v", err)
+	}
+
+	failures := logs.FilterMessage("Document text template failed").All()
+	if len(failures) != 1 {
+		t.Fatalf("logged %d template failures, want 1", len(failures))
+	}
+	fields := failures[0].ContextMap()
+	if fields["document"] != "names" || fields["field"] != "pages[0].blocks[0].text" {
+		t.Errorf("failure logged with %v, want the document and field path", fields)
+	}
+}
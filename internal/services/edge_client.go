@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrCircuitOpen is returned without calling the edge function while it is failing
+var ErrCircuitOpen = errors.New("edge function circuit open")
+
+// ErrResponseTooLarge is returned when an edge function response exceeds MaxResponseBytes
+var ErrResponseTooLarge = errors.New("edge function response too large")
+
+// EdgeClientOptions tunes calls to the Supabase edge functions. Zero fields take their
+// value from DefaultEdgeClientOptions.
+type EdgeClientOptions struct {
+	APIKey           string        // Sent as the apikey header and as Authorization: Bearer
+	AttemptTimeout   time.Duration // Deadline for each attempt, including reading the body
+	MaxAttempts      int           // Attempts per call, including the first
+	BaseBackoff      time.Duration // Wait before the first retry; doubles for each retry after
+	MaxBackoff       time.Duration // Longest wait between attempts
+	MaxResponseBytes int64         // Larger responses fail with ErrResponseTooLarge
+	BreakerThreshold int           // Failed attempts in a row that open the circuit
+	BreakerCooldown  time.Duration // How long the circuit stays open before a trial attempt
+}
+
+// DefaultEdgeClientOptions are used for any option left at zero
+var DefaultEdgeClientOptions = EdgeClientOptions{
+	AttemptTimeout:   15 * time.Second,
+	MaxAttempts:      3,
+	BaseBackoff:      200 * time.Millisecond,
+	MaxBackoff:       2 * time.Second,
+	MaxResponseBytes: 32 << 20,
+	BreakerThreshold: 5,
+	BreakerCooldown:  30 * time.Second,
+}
+
+// withDefaults fills zero options from DefaultEdgeClientOptions
+func (o EdgeClientOptions) withDefaults() EdgeClientOptions {
+	d := DefaultEdgeClientOptions
+	if o.AttemptTimeout <= 0 {
+		o.AttemptTimeout = d.AttemptTimeout
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = d.MaxAttempts
+	}
+	if o.BaseBackoff <= 0 {
+		o.BaseBackoff = d.BaseBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = d.MaxBackoff
+	}
+	if o.MaxResponseBytes <= 0 {
+		o.MaxResponseBytes = d.MaxResponseBytes
+	}
+	if o.BreakerThreshold <= 0 {
+		o.BreakerThreshold = d.BreakerThreshold
+	}
+	if o.BreakerCooldown <= 0 {
+		o.BreakerCooldown = d.BreakerCooldown
+	}
+	return o
+}
+
+// edgeClient makes authenticated GET requests to the edge functions. Server errors, 429s,
+// timeouts and connection failures are retried with jittered backoff; a circuit breaker
+// fails calls fast while the edge functions keep failing.
+type edgeClient struct {
+	logger     *zap.Logger
+	baseURL    string
+	opts       EdgeClientOptions
+	httpClient *http.Client
+	breaker    *circuitBreaker
+}
+
+// newEdgeClient creates a client for the edge functions under baseURL
+func newEdgeClient(logger *zap.Logger, baseURL string, opts EdgeClientOptions) *edgeClient {
+	opts = opts.withDefaults()
+	return &edgeClient{
+		logger:     logger,
+		baseURL:    baseURL,
+		opts:       opts,
+		httpClient: &http.Client{}, // Deadlines come from the per-attempt context
+		breaker:    newCircuitBreaker(opts.BreakerThreshold, opts.BreakerCooldown),
+	}
+}
+
+// edgeResponse is a completed edge function response
+type edgeResponse struct {
+	StatusCode int
+	Body       []byte
+}
+
+// get fetches path, retrying transient failures. A server error that is still failing after
+// the last attempt is returned as a response, so callers report its status and body.
+func (c *edgeClient) get(ctx context.Context, path string) (*edgeResponse, error) {
+	url := c.baseURL + path
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		if err := c.breaker.allow(); err != nil {
+			if lastErr != nil {
+				return nil, fmt.Errorf("%w (last error: %v)", err, lastErr)
+			}
+			return nil, err
+		}
+
+		resp, err := c.attempt(ctx, url)
+		if ctx.Err() != nil {
+			// The caller gave up; that says nothing about the edge function's health
+			c.breaker.release()
+			return nil, ctx.Err()
+		}
+
+		retryable := err != nil && !errors.Is(err, ErrResponseTooLarge)
+		if err == nil {
+			retryable = retryableStatus(resp.StatusCode)
+		}
+		c.breaker.record(!retryable)
+		if !retryable {
+			return resp, err
+		}
+
+		if err != nil {
+			lastErr = err
+		} else {
+			lastErr = fmt.Errorf("status %d", resp.StatusCode)
+		}
+		if attempt >= c.opts.MaxAttempts {
+			if err != nil {
+				return nil, fmt.Errorf("edge function failed after %d attempts: %w", attempt, err)
+			}
+			return resp, nil
+		}
+
+		wait := c.backoff(attempt)
+		c.logger.Warn("Edge function attempt failed, retrying",
+			zap.String("url", url),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", wait),
+			zap.NamedError("reason", lastErr))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt makes one request under its own deadline, reading at most MaxResponseBytes
+func (c *edgeClient) attempt(ctx context.Context, url string) (*edgeResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.AttemptTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.opts.APIKey != "" {
+		req.Header.Set("apikey", c.opts.APIKey)
+		req.Header.Set("Authorization", "Bearer "+c.opts.APIKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch data from edge function: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read one byte past the cap to tell a full-size body from a larger one
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.opts.MaxResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if int64(len(body)) > c.opts.MaxResponseBytes {
+		return nil, fmt.Errorf("%w: over %d bytes", ErrResponseTooLarge, c.opts.MaxResponseBytes)
+	}
+
+	return &edgeResponse{StatusCode: resp.StatusCode, Body: body}, nil
+}
+
+// backoff is the wait before the given retry: exponential up to MaxBackoff, with the
+// upper half randomised so parallel callers do not retry in step
+func (c *edgeClient) backoff(attempt int) time.Duration {
+	wait := c.opts.BaseBackoff << (attempt - 1)
+	if wait <= 0 || wait > c.opts.MaxBackoff {
+		wait = c.opts.MaxBackoff
+	}
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryableStatus reports whether a response status is worth retrying
+func retryableStatus(status int) bool {
+	return status >= 500 || status == http.StatusTooManyRequests
+}
+
+// circuitBreaker stops calls to a failing dependency. After threshold failures in a row it
+// opens and rejects calls for the cooldown, then lets one trial call through: success
+// closes it again, failure reopens it for another cooldown.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool // A trial call is in flight after the cooldown
+}
+
+// newCircuitBreaker creates a closed breaker
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow returns ErrCircuitOpen if a call may not be made now. Every allowed call must be
+// followed by record or release.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+	if b.trial || b.now().Before(b.openUntil) {
+		return fmt.Errorf("%w: failing fast until %s", ErrCircuitOpen, b.openUntil.Format(time.RFC3339))
+	}
+	b.trial = true
+	return nil
+}
+
+// record counts the result of an allowed call
+func (b *circuitBreaker) record(ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if ok {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
+
+// release ends an allowed call without counting it, e.g. when the caller cancelled
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
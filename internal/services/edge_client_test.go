@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// testEdgeOptions keeps retries, timeouts and the breaker short enough for tests
+func testEdgeOptions() EdgeClientOptions {
+	return EdgeClientOptions{
+		APIKey:           "test-key",
+		AttemptTimeout:   200 * time.Millisecond,
+		MaxAttempts:      3,
+		BaseBackoff:      time.Millisecond,
+		MaxBackoff:       5 * time.Millisecond,
+		MaxResponseBytes: 1 << 10,
+		BreakerThreshold: 4,
+		BreakerCooldown:  time.Minute,
+	}
+}
+
+// edgeServer serves the paperwork-data function with handler and counts the requests
+func edgeServer(t *testing.T, handler http.HandlerFunc) (*EventService, *int32) {
+	t.Helper()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return NewEventService(zap.NewNop(), server.URL, testEdgeOptions()), &requests
+}
+
+// writeEvent answers with a minimal event
+func writeEvent(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(`{"event": {"eid": "AB4000", "name": "Big Night"}}`))
+}
+
+func TestEdgeClientSendsKeyAndEscapedEID(t *testing.T) {
+	s, _ := edgeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("apikey") != "test-key" || r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("headers apikey %q, Authorization %q", r.Header.Get("apikey"), r.Header.Get("Authorization"))
+		}
+		if r.URL.EscapedPath() != "/functions/v1/paperwork-data/AB4000" {
+			t.Errorf("path = %s", r.URL.EscapedPath())
+		}
+		writeEvent(w, r)
+	})
+	data, err := s.GetEventPaperworkData(context.Background(), "AB4000")
+	if err != nil || data.Event.Name != "Big Night" {
+		t.Fatalf("GetEventPaperworkData = %v, %v", data, err)
+	}
+
+	// An EID that is not a single path segment stays one
+	s, _ = edgeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/functions/v1/paperwork-data/..%2Fadmin%3Fx=1" {
+			t.Errorf("path = %s", r.URL.EscapedPath())
+		}
+		writeEvent(w, r)
+	})
+	if _, err := s.GetEventPaperworkData(context.Background(), "../admin?x=1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEdgeClientRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  func(attempt int32) http.HandlerFunc
+		requests int32
+		wantErr  error
+	}{
+		{"5xx then success", func(attempt int32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if attempt < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				writeEvent(w, r)
+			}
+		}, 3, nil},
+		{"429 then success", func(attempt int32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if attempt < 2 {
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				writeEvent(w, r)
+			}
+		}, 2, nil},
+		{"timeout then success", func(attempt int32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if attempt < 2 {
+					<-r.Context().Done()
+					return
+				}
+				writeEvent(w, r)
+			}
+		}, 2, nil},
+		{"5xx every attempt", func(attempt int32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		}, 3, ErrUpstreamUnavailable},
+		{"404 is not retried", func(attempt int32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}, 1, ErrEventNotFound},
+		{"400 is not retried", func(attempt int32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		}, 1, ErrUpstreamUnavailable},
+		{"401 is not retried", func(attempt int32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}, 1, ErrUpstreamUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			s, requests := edgeServer(t, func(w http.ResponseWriter, r *http.Request) {
+				tt.handler(atomic.AddInt32(&attempts, 1))(w, r)
+			})
+			_, err := s.GetEventPaperworkData(context.Background(), "AB4000")
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got := atomic.LoadInt32(requests); got != tt.requests {
+				t.Errorf("%d requests, want %d", got, tt.requests)
+			}
+		})
+	}
+}
+
+func TestEdgeClientBackoff(t *testing.T) {
+	c := newEdgeClient(zap.NewNop(), "", EdgeClientOptions{})
+	// Doubles from BaseBackoff up to MaxBackoff, each wait in the upper half of its step
+	for attempt, step := range []time.Duration{200, 400, 800, 1600, 2000, 2000, 2000} {
+		step *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			if wait := c.backoff(attempt + 1); wait < step/2 || wait > step {
+				t.Fatalf("backoff(%d) = %v, want %v to %v", attempt+1, wait, step/2, step)
+			}
+		}
+	}
+	// A shift past the duration's range still waits MaxBackoff
+	if wait := c.backoff(80); wait < time.Second || wait > 2*time.Second {
+		t.Errorf("backoff(80) = %v", wait)
+	}
+}
+
+func TestEdgeClientSizeCap(t *testing.T) {
+	s, requests := edgeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"event": {"name": "` + strings.Repeat("x", 2<<10) + `"}}`))
+	})
+	_, err := s.GetEventPaperworkData(context.Background(), "AB4000")
+	if !errors.Is(err, ErrUpstreamBadData) || !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("err = %v, want ErrUpstreamBadData wrapping ErrResponseTooLarge", err)
+	}
+	// Asking again would get the same body
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("%d requests, want 1", got)
+	}
+
+	// A body exactly at the cap is read
+	s, _ = edgeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body := `{"event": {"name": "`
+		w.Write([]byte(body + strings.Repeat("x", 1<<10-len(body)-3) + `"}}`))
+	})
+	if _, err := s.GetEventPaperworkData(context.Background(), "AB4000"); err != nil {
+		t.Errorf("body at the cap: %v", err)
+	}
+}
+
+func TestEdgeClientCircuitBreaker(t *testing.T) {
+	var down int32 = 1
+	s, requests := edgeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&down) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writeEvent(w, r)
+	})
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.edge.breaker.now = func() time.Time { return now }
+	fetch := func() error {
+		_, err := s.GetEventPaperworkData(context.Background(), "AB4000")
+		return err
+	}
+	expect := func(step string, err error, wantOpen bool, wantRequests int32) {
+		t.Helper()
+		if errors.Is(err, ErrCircuitOpen) != wantOpen {
+			t.Errorf("%s: err = %v, want circuit open %v", step, err, wantOpen)
+		}
+		if got := atomic.LoadInt32(requests); got != wantRequests {
+			t.Errorf("%s: %d requests, want %d", step, got, wantRequests)
+		}
+	}
+
+	// Three failed attempts, then the fourth opens the circuit part way through the next call
+	expect("first call", fetch(), false, 3)
+	err := fetch()
+	expect("second call", err, true, 4)
+	if !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Errorf("open circuit err = %v, want ErrUpstreamUnavailable", err)
+	}
+
+	// Open: no requests until the cooldown is over
+	expect("while open", fetch(), true, 4)
+	now = now.Add(time.Minute - time.Second)
+	expect("just before the cooldown ends", fetch(), true, 4)
+
+	// Half open: one trial, which fails and opens the circuit again
+	now = now.Add(time.Second)
+	expect("failed trial", fetch(), true, 5)
+	expect("after the failed trial", fetch(), true, 5)
+
+	// A successful trial closes it
+	atomic.StoreInt32(&down, 0)
+	now = now.Add(time.Minute)
+	expect("successful trial", fetch(), false, 6)
+	expect("closed", fetch(), false, 7)
+}
+
+func TestEdgeClientCancelledCallIsNotAFailure(t *testing.T) {
+	s, _ := edgeServer(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := s.GetEventPaperworkData(ctx, "AB4000"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want the caller's deadline", err)
+	}
+	if s.edge.breaker.failures != 0 {
+		t.Errorf("breaker counted %d failures for a cancelled call", s.edge.breaker.failures)
+	}
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrEventNotFound is returned when the event data source has no event with the EID
+	ErrEventNotFound = errors.New("event not found")
+	// ErrUpstreamUnavailable is returned when the event data source could not be reached,
+	// kept failing or is being failed fast by the circuit breaker
+	ErrUpstreamUnavailable = errors.New("event data service unavailable")
+	// ErrUpstreamBadData is returned when the event data source answered with data that
+	// could not be used, e.g. malformed JSON or a response over the size cap
+	ErrUpstreamBadData = errors.New("event data service returned bad data")
+	// ErrNoArtists is returned when an event has no artists to print
+	ErrNoArtists = errors.New("no artists found for event")
+	// ErrRenderFailed matches every RenderError with errors.Is
+	ErrRenderFailed = errors.New("render failed")
+)
+
+// RenderError is a PDF rendering failure. Section is the built-in section, or the
+// declarative document, that was being drawn; it is empty if the failure came after
+// every section was drawn.
+type RenderError struct {
+	Section Section
+	Err     error
+}
+
+// Error describes the failure and where it happened
+func (e *RenderError) Error() string {
+	if e.Section == "" {
+		return fmt.Sprintf("render failed: %v", e.Err)
+	}
+	return fmt.Sprintf("render failed in %s: %v", e.Section, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *RenderError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is(err, ErrRenderFailed) true for any RenderError
+func (e *RenderError) Is(target error) bool {
+	return target == ErrRenderFailed
+}
@@ -3,10 +3,10 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"time"
+	"net/url"
 
 	"paperwork-service/internal/models"
 
@@ -15,63 +15,51 @@ import (
 
 // EventService handles fetching event data from Supabase edge functions
 type EventService struct {
-	logger      *zap.Logger
-	supabaseURL string
-	httpClient  *http.Client
+	logger *zap.Logger
+	edge   *edgeClient
 }
 
-// NewEventService creates a new event service
-func NewEventService(logger *zap.Logger, supabaseURL string) *EventService {
+// NewEventService creates a new event service. opts sets the API key and how calls are
+// retried and cut off; see EdgeClientOptions.
+func NewEventService(logger *zap.Logger, supabaseURL string, opts EdgeClientOptions) *EventService {
 	return &EventService{
-		logger:      logger,
-		supabaseURL: supabaseURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		logger: logger,
+		edge:   newEdgeClient(logger, supabaseURL, opts),
 	}
 }
 
 // PaperworkData represents the response from the paperwork-data edge function
 type PaperworkData struct {
-	Event       models.Event             `json:"event"`
-	Artists     []models.EventArtist     `json:"artists"`
-	AuctionLots []models.AuctionLot      `json:"auction_lots"`
-	TotalArtists int                     `json:"total_artists"`
-	TotalBids    int                     `json:"total_bids"`
-	GeneratedAt  string                  `json:"generated_at"`
+	Event        models.Event         `json:"event"`
+	Artists      []models.EventArtist `json:"artists"`
+	AuctionLots  []models.AuctionLot  `json:"auction_lots"`
+	TotalArtists int                  `json:"total_artists"`
+	TotalBids    int                  `json:"total_bids"`
+	GeneratedAt  string               `json:"generated_at"`
 }
 
-// GetEventPaperworkData fetches all data needed for paperwork generation
+// GetEventPaperworkData fetches all data needed for paperwork generation. Failures wrap
+// ErrEventNotFound, ErrUpstreamUnavailable or ErrUpstreamBadData, except cancellation,
+// which returns the context's error.
 func (s *EventService) GetEventPaperworkData(ctx context.Context, eid string) (*PaperworkData, error) {
 	s.logger.Info("Fetching paperwork data for event", zap.String("eid", eid))
 
-	// Build the edge function URL
-	url := fmt.Sprintf("%s/functions/v1/paperwork-data/%s", s.supabaseURL, eid)
-
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-
-	// Make the request
-	resp, err := s.httpClient.Do(req)
+	// Fetch from the edge function, retrying transient failures
+	path := "/functions/v1/paperwork-data/" + url.PathEscape(eid)
+	resp, err := s.edge.get(ctx, path)
 	if err != nil {
 		s.logger.Error("Failed to make request to edge function",
-			zap.String("url", url),
+			zap.String("eid", eid),
 			zap.Error(err))
-		return nil, fmt.Errorf("failed to fetch data from edge function: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		if errors.Is(err, ErrResponseTooLarge) {
+			return nil, fmt.Errorf("%w: %w", ErrUpstreamBadData, err)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrUpstreamUnavailable, err)
 	}
+	body := resp.Body
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
@@ -81,9 +69,9 @@ func (s *EventService) GetEventPaperworkData(ctx context.Context, eid string) (*
 			zap.String("response", string(body)))
 
 		if resp.StatusCode == http.StatusNotFound {
-			return nil, fmt.Errorf("event not found: %s", eid)
+			return nil, fmt.Errorf("%w: %s", ErrEventNotFound, eid)
 		}
-		return nil, fmt.Errorf("edge function error (status %d): %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("%w: edge function error (status %d): %s", ErrUpstreamUnavailable, resp.StatusCode, string(body))
 	}
 
 	// Parse JSON response
@@ -93,7 +81,7 @@ func (s *EventService) GetEventPaperworkData(ctx context.Context, eid string) (*
 			zap.String("eid", eid),
 			zap.Error(err),
 			zap.String("response", string(body)))
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, fmt.Errorf("%w: failed to parse response: %w", ErrUpstreamBadData, err)
 	}
 
 	s.logger.Info("Successfully fetched paperwork data",
@@ -112,4 +100,4 @@ func (s *EventService) GetEventByEID(ctx context.Context, eid string) (*models.E
 		return nil, err
 	}
 	return &data.Event, nil
-}
\ No newline at end of file
+}
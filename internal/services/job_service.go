@@ -0,0 +1,400 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Job lifecycle states
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+	JobCancelled = "cancelled"
+)
+
+// Section progress states within a job
+const (
+	SectionPending = "pending"
+	SectionRunning = "running"
+	SectionDone    = "done"
+)
+
+var (
+	// ErrJobNotFound is returned for unknown or expired job IDs
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobQueueFull is returned when every worker is busy and the queue is at capacity
+	ErrJobQueueFull = errors.New("job queue is full")
+	// ErrJobServiceClosed is returned once shutdown has started
+	ErrJobServiceClosed = errors.New("job service is shutting down")
+	// ErrJobNotReady is returned when a result is requested before the job has succeeded
+	ErrJobNotReady = errors.New("job result is not ready")
+)
+
+// SectionProgress reports the rendering state of one section of a job
+type SectionProgress struct {
+	Section Section `json:"section"`
+	Status  string  `json:"status"`
+}
+
+// JobStatus is a point-in-time snapshot of a paperwork job
+type JobStatus struct {
+	ID         string            `json:"id"`
+	EID        string            `json:"eid"`
+	Status     string            `json:"status"`
+	Sections   []SectionProgress `json:"sections"`
+	Profile    Profile           `json:"profile"`
+	SizeBytes  int               `json:"size_bytes,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	StartedAt  *time.Time        `json:"started_at,omitempty"`
+	FinishedAt *time.Time        `json:"finished_at,omitempty"`
+
+	// Err is why a failed job failed. It can hold upstream response text, so it is only
+	// logged; the API reports the classified message instead.
+	Err error `json:"-"`
+	// Owner identifies the caller that created the job, empty for an unauthenticated one
+	Owner string `json:"-"`
+}
+
+// job is the mutable state behind a JobStatus; guarded by JobService.mu
+type job struct {
+	status   JobStatus
+	sections []Section
+	result   []byte
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// JobService renders event paperwork asynchronously on a bounded worker pool
+type JobService struct {
+	logger     *zap.Logger
+	eventData  EventDataProvider
+	pdfService *PaperworkPDFService
+	workers    int
+	resultTTL  time.Duration
+
+	mu     sync.Mutex
+	jobs   map[string]*job
+	queue  chan *job
+	closed bool
+
+	wg   sync.WaitGroup
+	stop chan struct{}
+}
+
+// NewJobService creates a job service with the given number of workers and queue capacity.
+// Finished jobs and their results are kept for resultTTL.
+func NewJobService(logger *zap.Logger, eventData EventDataProvider, pdfService *PaperworkPDFService, workers int, queueSize int, resultTTL time.Duration) *JobService {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	return &JobService{
+		logger:     logger,
+		eventData:  eventData,
+		pdfService: pdfService,
+		workers:    workers,
+		resultTTL:  resultTTL,
+		jobs:       make(map[string]*job),
+		queue:      make(chan *job, queueSize),
+		stop:       make(chan struct{}),
+	}
+}
+
+// Start launches the worker pool and the expiry janitor
+func (s *JobService) Start() {
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.worker()
+	}
+	go s.janitor()
+
+	s.logger.Info("Job service started",
+		zap.Int("workers", s.workers),
+		zap.Int("queue_size", cap(s.queue)))
+}
+
+// Submit queues a paperwork job for an event, rendered with the given profile. owner
+// identifies the caller, so the API can limit the job to it.
+func (s *JobService) Submit(eid string, sections []Section, profile Profile, owner string) (JobStatus, error) {
+	if len(sections) == 0 {
+		sections = DefaultSections
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return JobStatus{}, fmt.Errorf("failed to create job ID: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &job{
+		status: JobStatus{
+			ID:        id,
+			EID:       eid,
+			Status:    JobQueued,
+			Profile:   profile,
+			Owner:     owner,
+			Sections:  make([]SectionProgress, len(sections)),
+			CreatedAt: time.Now().UTC(),
+		},
+		sections: sections,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	for i, section := range sections {
+		j.status.Sections[i] = SectionProgress{Section: section, Status: SectionPending}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		cancel()
+		return JobStatus{}, ErrJobServiceClosed
+	}
+
+	select {
+	case s.queue <- j:
+	default:
+		cancel()
+		return JobStatus{}, ErrJobQueueFull
+	}
+	s.jobs[id] = j
+
+	s.logger.Info("Queued paperwork job",
+		zap.String("job_id", id),
+		zap.String("eid", eid))
+
+	return snapshot(j), nil
+}
+
+// Get returns the current status of a job
+func (s *JobService) Get(id string) (JobStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return JobStatus{}, ErrJobNotFound
+	}
+	return snapshot(j), nil
+}
+
+// Result returns the PDF of a succeeded job along with its status
+func (s *JobService) Result(id string) ([]byte, JobStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, JobStatus{}, ErrJobNotFound
+	}
+	if j.status.Status != JobSucceeded {
+		return nil, snapshot(j), ErrJobNotReady
+	}
+	return j.result, snapshot(j), nil
+}
+
+// Cancel stops a queued or running job. Cancelling a finished job has no effect.
+func (s *JobService) Cancel(id string) (JobStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[id]
+	if !ok {
+		return JobStatus{}, ErrJobNotFound
+	}
+
+	switch j.status.Status {
+	case JobQueued:
+		// The worker skips jobs that are already cancelled
+		s.finish(j, JobCancelled, nil)
+		j.cancel()
+	case JobRunning:
+		// The worker records the cancellation when rendering stops
+		j.cancel()
+	}
+
+	return snapshot(j), nil
+}
+
+// Shutdown stops accepting jobs and waits for queued and running jobs to finish.
+// If ctx expires first, the remaining jobs are cancelled and awaited.
+func (s *JobService) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.queue)
+	close(s.stop)
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("Job service drained")
+		return nil
+	case <-ctx.Done():
+	}
+
+	// Out of time: cancel everything still outstanding and wait for workers to notice
+	s.mu.Lock()
+	for _, j := range s.jobs {
+		j.cancel()
+	}
+	s.mu.Unlock()
+	<-done
+
+	s.logger.Warn("Job service shutdown cancelled unfinished jobs")
+	return ctx.Err()
+}
+
+// worker runs queued jobs until the queue is closed
+func (s *JobService) worker() {
+	defer s.wg.Done()
+
+	for j := range s.queue {
+		s.run(j)
+	}
+}
+
+// run executes one job and records its outcome
+func (s *JobService) run(j *job) {
+	s.mu.Lock()
+	if j.status.Status != JobQueued {
+		s.mu.Unlock()
+		return
+	}
+	now := time.Now().UTC()
+	j.status.Status = JobRunning
+	j.status.StartedAt = &now
+	eid := j.status.EID
+	s.mu.Unlock()
+
+	logger := s.logger.With(zap.String("job_id", j.status.ID), zap.String("eid", eid))
+	logger.Info("Running paperwork job")
+
+	pdfData, err := s.render(j, eid)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case err == nil:
+		j.result = pdfData
+		j.status.SizeBytes = len(pdfData)
+		s.finish(j, JobSucceeded, nil)
+		logger.Info("Paperwork job succeeded", zap.Int("pdf_size_bytes", len(pdfData)))
+	case j.ctx.Err() != nil:
+		s.finish(j, JobCancelled, nil)
+		logger.Info("Paperwork job cancelled")
+	default:
+		s.finish(j, JobFailed, err)
+		logger.Error("Paperwork job failed", zap.Error(err))
+	}
+	j.cancel()
+}
+
+// render fetches the event data and builds the PDF, updating section progress as it goes
+func (s *JobService) render(j *job, eid string) ([]byte, error) {
+	data, err := s.eventData.GetEventPaperworkData(j.ctx, eid)
+	if err != nil {
+		return nil, err
+	}
+	NormalizePaperworkData(data)
+
+	if len(data.Artists) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoArtists, eid)
+	}
+
+	progress := func(section Section, done bool) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for i := range j.status.Sections {
+			if j.status.Sections[i].Section == section {
+				if done {
+					j.status.Sections[i].Status = SectionDone
+				} else {
+					j.status.Sections[i].Status = SectionRunning
+				}
+			}
+		}
+	}
+
+	return s.pdfService.GenerateEventPaperworkContext(j.ctx, &data.Event, data.Artists, data.AuctionLots, RenderOptions{
+		Sections: j.sections,
+		Profile:  j.status.Profile,
+		Progress: progress,
+	})
+}
+
+// finish records a terminal state; callers must hold s.mu
+func (s *JobService) finish(j *job, status string, err error) {
+	now := time.Now().UTC()
+	j.status.Status = status
+	j.status.Err = err
+	j.status.FinishedAt = &now
+}
+
+// janitor evicts finished jobs once their results have expired
+func (s *JobService) janitor() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.evictExpired(time.Now().UTC())
+		}
+	}
+}
+
+// evictExpired removes finished jobs older than the result TTL
+func (s *JobService) evictExpired(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, j := range s.jobs {
+		if j.status.FinishedAt != nil && now.Sub(*j.status.FinishedAt) > s.resultTTL {
+			delete(s.jobs, id)
+			s.logger.Debug("Evicted expired job", zap.String("job_id", id))
+		}
+	}
+}
+
+// snapshot copies a job's status so callers can read it without the lock; callers must hold s.mu
+func snapshot(j *job) JobStatus {
+	status := j.status
+	status.Sections = append([]SectionProgress(nil), j.status.Sections...)
+	return status
+}
+
+// newJobID returns a random 128-bit hex identifier
+func newJobID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// stubProvider serves event data from a function, so tests can block, fail or succeed
+type stubProvider struct {
+	get func(ctx context.Context, eid string) (*PaperworkData, error)
+}
+
+func (p stubProvider) Name() string {
+	return "stub"
+}
+
+func (p stubProvider) GetEventPaperworkData(ctx context.Context, eid string) (*PaperworkData, error) {
+	return p.get(ctx, eid)
+}
+
+// sampleProvider serves the designer sample event for every EID
+func sampleProvider() stubProvider {
+	return stubProvider{get: func(ctx context.Context, eid string) (*PaperworkData, error) {
+		event, artists, lots := SampleEvent()
+		return &PaperworkData{Event: *event, Artists: artists, AuctionLots: lots}, nil
+	}}
+}
+
+// blockingProvider signals on started and then blocks until the job is cancelled
+func blockingProvider(started chan<- string) stubProvider {
+	return stubProvider{get: func(ctx context.Context, eid string) (*PaperworkData, error) {
+		started <- eid
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}}
+}
+
+func newTestJobService(t *testing.T, provider EventDataProvider, workers, queueSize int) *JobService {
+	t.Helper()
+	pdfService := NewPaperworkPDFService(zap.NewNop(), "../../templates", DefaultBioPolicy, DefaultLayout(), nil)
+	return NewJobService(zap.NewNop(), provider, pdfService, workers, queueSize, time.Hour)
+}
+
+// waitForStatus polls a job until it reaches the wanted status
+func waitForStatus(t *testing.T, s *JobService, id, want string) JobStatus {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		status, err := s.Get(id)
+		if err != nil {
+			t.Fatalf("Get(%s): %v", id, err)
+		}
+		if status.Status == want {
+			return status
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job %s is %q, want %q", id, status.Status, want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestJobServiceSucceeds(t *testing.T) {
+	s := newTestJobService(t, sampleProvider(), 1, 1)
+	s.Start()
+	defer s.Shutdown(context.Background())
+
+	status, err := s.Submit("AB1", []Section{SectionAuction}, ProfileStaff, "")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if status.Status != JobQueued {
+		t.Errorf("new job is %q, want %q", status.Status, JobQueued)
+	}
+
+	status = waitForStatus(t, s, status.ID, JobSucceeded)
+	if status.Sections[0].Status != SectionDone {
+		t.Errorf("section is %q, want %q", status.Sections[0].Status, SectionDone)
+	}
+
+	pdf, _, err := s.Result(status.ID)
+	if err != nil {
+		t.Fatalf("Result: %v", err)
+	}
+	if len(pdf) != status.SizeBytes || len(pdf) == 0 {
+		t.Errorf("result is %d bytes, status reports %d", len(pdf), status.SizeBytes)
+	}
+}
+
+func TestJobServiceQueueFull(t *testing.T) {
+	// Without Start nothing drains the queue, so the second job has nowhere to go
+	s := newTestJobService(t, sampleProvider(), 1, 1)
+
+	if _, err := s.Submit("AB1", nil, ProfileStaff, ""); err != nil {
+		t.Fatalf("first Submit: %v", err)
+	}
+	if _, err := s.Submit("AB2", nil, ProfileStaff, ""); !errors.Is(err, ErrJobQueueFull) {
+		t.Fatalf("second Submit error = %v, want %v", err, ErrJobQueueFull)
+	}
+}
+
+func TestJobServiceCancelQueued(t *testing.T) {
+	s := newTestJobService(t, sampleProvider(), 1, 1)
+
+	status, err := s.Submit("AB1", nil, ProfileStaff, "")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	status, err = s.Cancel(status.ID)
+	if err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if status.Status != JobCancelled || status.FinishedAt == nil {
+		t.Fatalf("cancelled queued job is %q (finished %v)", status.Status, status.FinishedAt)
+	}
+
+	// The worker skips the cancelled job instead of rendering it
+	s.Start()
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if status, _ := s.Get(status.ID); status.Status != JobCancelled || status.StartedAt != nil {
+		t.Errorf("cancelled job ran: %+v", status)
+	}
+}
+
+func TestJobServiceCancelRunning(t *testing.T) {
+	started := make(chan string, 1)
+	s := newTestJobService(t, blockingProvider(started), 1, 1)
+	s.Start()
+	defer s.Shutdown(context.Background())
+
+	status, err := s.Submit("AB1", nil, ProfileStaff, "")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	<-started
+
+	if _, err := s.Cancel(status.ID); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	status = waitForStatus(t, s, status.ID, JobCancelled)
+	if status.Err != nil {
+		t.Errorf("cancelled job has error %v", status.Err)
+	}
+	if _, _, err := s.Result(status.ID); !errors.Is(err, ErrJobNotReady) {
+		t.Errorf("Result error = %v, want %v", err, ErrJobNotReady)
+	}
+}
+
+func TestJobServiceFailure(t *testing.T) {
+	s := newTestJobService(t, stubProvider{get: func(ctx context.Context, eid string) (*PaperworkData, error) {
+		return &PaperworkData{}, nil
+	}}, 1, 1)
+	s.Start()
+	defer s.Shutdown(context.Background())
+
+	status, err := s.Submit("AB1", nil, ProfileStaff, "")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	status = waitForStatus(t, s, status.ID, JobFailed)
+	if !errors.Is(status.Err, ErrNoArtists) {
+		t.Errorf("failed job error = %v, want ErrNoArtists", status.Err)
+	}
+}
+
+func TestJobServiceShutdownDrains(t *testing.T) {
+	s := newTestJobService(t, sampleProvider(), 1, 2)
+	s.Start()
+
+	first, _ := s.Submit("AB1", []Section{SectionAuction}, ProfileStaff, "")
+	second, _ := s.Submit("AB2", []Section{SectionAuction}, ProfileStaff, "")
+
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	for _, id := range []string{first.ID, second.ID} {
+		if status, _ := s.Get(id); status.Status != JobSucceeded {
+			t.Errorf("job %s is %q after drain, want %q", id, status.Status, JobSucceeded)
+		}
+	}
+
+	if _, err := s.Submit("AB3", nil, ProfileStaff, ""); !errors.Is(err, ErrJobServiceClosed) {
+		t.Errorf("Submit after Shutdown error = %v, want %v", err, ErrJobServiceClosed)
+	}
+}
+
+func TestJobServiceShutdownTimeout(t *testing.T) {
+	started := make(chan string, 1)
+	s := newTestJobService(t, blockingProvider(started), 1, 1)
+	s.Start()
+
+	running, _ := s.Submit("AB1", nil, ProfileStaff, "")
+	<-started
+	queued, _ := s.Submit("AB2", nil, ProfileStaff, "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// Shutdown only returns once the workers have stopped, so both jobs are settled
+	for _, id := range []string{running.ID, queued.ID} {
+		if status, _ := s.Get(id); status.Status != JobCancelled {
+			t.Errorf("job %s is %q after shutdown timeout, want %q", id, status.Status, JobCancelled)
+		}
+	}
+}
+
+func TestJobServiceEvictExpired(t *testing.T) {
+	s := newTestJobService(t, sampleProvider(), 1, 2)
+
+	finished, _ := s.Submit("AB1", nil, ProfileStaff, "")
+	finished, _ = s.Cancel(finished.ID)
+	queued, _ := s.Submit("AB2", nil, ProfileStaff, "")
+
+	s.evictExpired(finished.FinishedAt.Add(s.resultTTL))
+	if _, err := s.Get(finished.ID); err != nil {
+		t.Fatalf("job evicted at exactly the TTL: %v", err)
+	}
+
+	s.evictExpired(finished.FinishedAt.Add(s.resultTTL + time.Second))
+	if _, err := s.Get(finished.ID); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("expired job error = %v, want %v", err, ErrJobNotFound)
+	}
+	if _, err := s.Get(queued.ID); err != nil {
+		t.Errorf("unfinished job was evicted: %v", err)
+	}
+}
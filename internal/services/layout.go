@@ -0,0 +1,520 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"text/template"
+
+	"paperwork-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Letter landscape page size in mm
+const (
+	pageWidth  = 279.4
+	pageHeight = 215.9
+)
+
+// customFonts are the Acumin Pro fonts registered on every document, by layout font name
+var customFonts = map[string]string{
+	"AcuminMedium":   "Acumin Pro SemiCond Medium.ttf",
+	"AcuminBold":     "Acumin Pro Cond Bold.ttf",
+	"AcuminSemibold": "Acumin Pro SemiCond Semibold.ttf",
+}
+
+// Layout positions the fields and tables on each paperwork page. It is read from
+// templates/pdf/configs/template-config.json so designers can move elements without a release.
+type Layout struct {
+	ArtistPage     ArtistPageLayout     `json:"artistPage"`
+	ArtistListPage ArtistListPageLayout `json:"artistListPage"`
+	AuctionPage    AuctionPageLayout    `json:"auctionPage"`
+
+	// Unused lists the config values the service has nothing to draw with, such as the
+	// instagram field of the original designer schema, so they can be reported at startup
+	Unused []string `json:"-"`
+}
+
+// ArtistPageLayout is the layout of the individual artist pages
+type ArtistPageLayout struct {
+	Fields struct {
+		EventHistory LayoutField `json:"eventHistory"`
+		Bio          LayoutField `json:"bio"`
+		QRCode       LayoutField `json:"qrCode"`
+		ArtistName   LayoutField `json:"artistName"`
+		EventName    LayoutField `json:"eventName"`
+		RoundEasel   LayoutField `json:"roundEasel"`
+	} `json:"fields"`
+}
+
+// ArtistListPageLayout is the layout of the artist list pages
+type ArtistListPageLayout struct {
+	Fields struct {
+		Title LayoutField `json:"title"`
+		Table LayoutTable `json:"table"`
+	} `json:"fields"`
+}
+
+// AuctionPageLayout is the layout of the auction pages. PublicTable is used when the
+// profile hides bidder and payment details.
+type AuctionPageLayout struct {
+	Fields struct {
+		Title       LayoutField `json:"title"`
+		Table       LayoutTable `json:"table"`
+		PublicTable LayoutTable `json:"publicTable"`
+	} `json:"fields"`
+}
+
+// LayoutField places one element on a page, in mm from the top-left corner
+type LayoutField struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+
+	// Text fields only
+	Font     string      `json:"font,omitempty"`
+	FontSize float64     `json:"fontSize,omitempty"`
+	Color    LayoutColor `json:"color"`
+	// MinFontSize lets text shrink to fit its box, down to this size (optional)
+	MinFontSize float64 `json:"minFontSize,omitempty"`
+	// LineHeight spaces multi-line text (optional)
+	LineHeight float64 `json:"lineHeight,omitempty"`
+	// MaxItems caps list fields such as the event history (optional)
+	MaxItems int `json:"maxItems,omitempty"`
+	// Columns flows a list field into side-by-side columns, each Width wide and ColumnGap
+	// apart (optional, one column by default)
+	Columns   int     `json:"columns,omitempty"`
+	ColumnGap float64 `json:"columnGap,omitempty"`
+	// Align is L, C or R within the field's width (optional, L by default)
+	Align string `json:"align,omitempty"`
+	// Text is a text/template replacing the field's usual text, e.g.
+	// "Round {{.RoundNumber}} · Easel {{.EaselNumber}}" (optional)
+	Text string `json:"text,omitempty"`
+
+	tmpl *template.Template // Text, parsed by Validate
+}
+
+// ArtistPageData is what artist page text templates run against: the artist's fields,
+// e.g. {{.Name}} or {{.RoundNumber}}, and the event as {{.Event.Venue}}
+type ArtistPageData struct {
+	DocArtist
+	Event *models.Event
+}
+
+// TitleData is what page title templates run against, e.g. {{.Event.Name}}
+type TitleData struct {
+	Event *models.Event
+}
+
+// LayoutColor is an RGB color, 0-255 per channel
+type LayoutColor struct {
+	R int `json:"r"`
+	G int `json:"g"`
+	B int `json:"b"`
+}
+
+// LayoutTable positions a table and sizes its columns. The header row uses RowHeight and
+// FontSize unless HeaderHeight or HeaderFontSize is set.
+type LayoutTable struct {
+	X              float64        `json:"x"`
+	Y              float64        `json:"y"`
+	RowHeight      float64        `json:"rowHeight"`
+	FontSize       float64        `json:"fontSize"`
+	HeaderHeight   float64        `json:"headerHeight,omitempty"`
+	HeaderFontSize float64        `json:"headerFontSize,omitempty"`
+	Columns        []LayoutColumn `json:"columns"`
+}
+
+// LayoutColumn is one table column. Align is L, C or R.
+type LayoutColumn struct {
+	Header string  `json:"header"`
+	Width  float64 `json:"width"`
+	Align  string  `json:"align"`
+}
+
+// DefaultLayout is the built-in layout, used for anything the config file leaves out
+func DefaultLayout() Layout {
+	const midPoint = pageHeight / 2 // The artist page is split into a top and a bottom half
+
+	var layout Layout
+
+	artist := &layout.ArtistPage.Fields
+	// Top half: event history on the left, bio on the right
+	artist.EventHistory = LayoutField{X: 20, Y: 10.05, Width: 115, Font: "AcuminMedium", FontSize: 12, LineHeight: 6, MaxItems: 20}
+	artist.Bio = LayoutField{X: 145, Y: 10.05, Width: 114.4, Height: 134.9, Font: "AcuminMedium", FontSize: 14, MinFontSize: 9, LineHeight: 6}
+	// Bottom half: QR code on the left, name and event details beside it
+	artist.QRCode = LayoutField{X: 20, Y: midPoint + 45, Width: 42, Height: 42}
+	artist.ArtistName = LayoutField{X: 70, Y: midPoint + 48, Width: 189.4, Height: 10, Font: "AcuminBold", FontSize: 49, MinFontSize: 20}
+	artist.EventName = LayoutField{X: 70, Y: midPoint + 69, Width: 189.4, Height: 6, Font: "AcuminMedium", FontSize: 18}
+	artist.RoundEasel = LayoutField{X: 70, Y: midPoint + 77, Width: 189.4, Height: 8, Font: "AcuminMedium", FontSize: 21}
+
+	list := &layout.ArtistListPage.Fields
+	list.Title = titleField(24)
+	list.Table = LayoutTable{X: tableLeft, Y: tableTop, RowHeight: 8, FontSize: 10, Columns: []LayoutColumn{
+		{Header: "Round-Easel", Width: 40, Align: "C"},
+		{Header: "Artist Name", Width: 130, Align: "L"},
+	}}
+
+	auction := &layout.AuctionPage.Fields
+	auction.Title = titleField(20)
+	auction.Table = LayoutTable{X: tableLeft, Y: tableTop, RowHeight: 8, FontSize: 9, Columns: []LayoutColumn{
+		{Header: "EID-Round-Easel", Width: 40, Align: "C"},
+		{Header: "Artist Name", Width: 60, Align: "L"},
+		{Header: "# Bids", Width: 20, Align: "C"},
+		{Header: "Top Bid", Width: 25, Align: "C"},
+		{Header: "Bidder Info", Width: 60, Align: "L"},
+		{Header: "Payment Status", Width: 35, Align: "C"},
+	}}
+	auction.PublicTable = LayoutTable{X: tableLeft, Y: tableTop, RowHeight: 8, FontSize: 9, Columns: []LayoutColumn{
+		{Header: "EID-Round-Easel", Width: 40, Align: "C"},
+		{Header: "Artist Name", Width: 135, Align: "L"},
+		{Header: "# Bids", Width: 30, Align: "C"},
+		{Header: "Top Bid", Width: 35, Align: "C"},
+	}}
+
+	return layout
+}
+
+// titleField is the page title position shared by the table and summary pages
+func titleField(fontSize float64) LayoutField {
+	return LayoutField{X: tableLeft, Y: 20, Width: pageWidth - 2*tableLeft, Height: 10, Font: "AcuminBold", FontSize: fontSize}
+}
+
+// LoadLayout reads a layout config file over DefaultLayout, so a file only needs the
+// values it changes, and validates the result. Unknown keys are rejected to catch typos.
+// A file in the original designer schema (see legacyLayout) is still read.
+func LoadLayout(path string) (Layout, error) {
+	layout := DefaultLayout()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return layout, fmt.Errorf("failed to read layout config: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&layout); err != nil {
+		// Report the current schema's error unless the file reads as the original one
+		layout = DefaultLayout()
+		if legacy, legacyErr := parseLegacyLayout(data); legacyErr == nil {
+			err = legacy.apply(&layout)
+		}
+		if err != nil {
+			return DefaultLayout(), fmt.Errorf("failed to parse layout config %s: %w", path, err)
+		}
+	}
+
+	if err := layout.Validate(); err != nil {
+		return DefaultLayout(), fmt.Errorf("invalid layout config %s: %w", path, err)
+	}
+	return layout, nil
+}
+
+// Validate checks that every field fits on the page, uses a registered font and
+// that each table has the columns its rows need. Text templates are parsed here, once.
+// All problems are reported together.
+func (l *Layout) Validate() error {
+	v := &layoutValidator{}
+	artistData := reflect.TypeOf(ArtistPageData{})
+	titleData := reflect.TypeOf(TitleData{})
+
+	artist := &l.ArtistPage.Fields
+	v.text("artistPage.fields.eventHistory", artist.EventHistory)
+	v.text("artistPage.fields.bio", artist.Bio)
+	v.image("artistPage.fields.qrCode", artist.QRCode)
+	v.text("artistPage.fields.artistName", artist.ArtistName)
+	v.text("artistPage.fields.eventName", artist.EventName)
+	v.text("artistPage.fields.roundEasel", artist.RoundEasel)
+	v.noText("artistPage.fields.eventHistory", artist.EventHistory)
+	v.noText("artistPage.fields.bio", artist.Bio)
+	v.noText("artistPage.fields.qrCode", artist.QRCode)
+	v.fieldTemplate("artistPage.fields.artistName", artistData, &artist.ArtistName)
+	v.fieldTemplate("artistPage.fields.eventName", artistData, &artist.EventName)
+	v.fieldTemplate("artistPage.fields.roundEasel", artistData, &artist.RoundEasel)
+	if artist.EventHistory.MaxItems <= 0 {
+		v.fail("artistPage.fields.eventHistory", "maxItems must be positive")
+	}
+	v.columns("artistPage.fields.eventHistory", artist.EventHistory)
+	v.list("artistPage.fields.eventHistory", artist.EventHistory)
+	if artist.Bio.Height <= 0 {
+		v.fail("artistPage.fields.bio", "height must be positive")
+	}
+	v.noOverlap(map[string]LayoutField{
+		"artistPage.fields.eventHistory": artist.EventHistory.listBox(),
+		"artistPage.fields.bio":          artist.Bio,
+		"artistPage.fields.qrCode":       artist.QRCode,
+		"artistPage.fields.artistName":   artist.ArtistName,
+		"artistPage.fields.eventName":    artist.EventName,
+		"artistPage.fields.roundEasel":   artist.RoundEasel,
+	})
+
+	v.text("artistListPage.fields.title", l.ArtistListPage.Fields.Title)
+	v.fieldTemplate("artistListPage.fields.title", titleData, &l.ArtistListPage.Fields.Title)
+	v.table("artistListPage.fields.table", l.ArtistListPage.Fields.Table, 2)
+
+	v.text("auctionPage.fields.title", l.AuctionPage.Fields.Title)
+	v.fieldTemplate("auctionPage.fields.title", titleData, &l.AuctionPage.Fields.Title)
+	v.table("auctionPage.fields.table", l.AuctionPage.Fields.Table, 6)
+	v.table("auctionPage.fields.publicTable", l.AuctionPage.Fields.PublicTable, 4)
+
+	return errors.Join(v.errs...)
+}
+
+// layoutValidator collects layout problems, each prefixed with the field's path in the config
+type layoutValidator struct {
+	errs []error
+}
+
+func (v *layoutValidator) fail(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+// box checks that a field's rectangle lies on the page
+func (v *layoutValidator) box(path string, f LayoutField) {
+	if f.X < 0 || f.Y < 0 {
+		v.fail(path, "x and y must not be negative")
+	}
+	if f.Width < 0 || f.Height < 0 {
+		v.fail(path, "width and height must not be negative")
+	}
+	if f.X+f.Width > pageWidth {
+		v.fail(path, "x + width (%.1f) is past the page width %.1f", f.X+f.Width, pageWidth)
+	}
+	if f.Y+f.Height > pageHeight {
+		v.fail(path, "y + height (%.1f) is past the page height %.1f", f.Y+f.Height, pageHeight)
+	}
+}
+
+// image checks a field that holds a picture, such as the QR code
+func (v *layoutValidator) image(path string, f LayoutField) {
+	v.box(path, f)
+	if f.Width <= 0 || f.Height <= 0 {
+		v.fail(path, "width and height must be positive")
+	}
+}
+
+// text checks a field that holds text
+func (v *layoutValidator) text(path string, f LayoutField) {
+	v.box(path, f)
+	if _, ok := customFonts[f.Font]; !ok {
+		v.fail(path, "unknown font %q (use one of %s)", f.Font, fontNames())
+	}
+	if f.FontSize <= 0 {
+		v.fail(path, "fontSize must be positive")
+	}
+	if f.MinFontSize < 0 || f.MinFontSize > f.FontSize {
+		v.fail(path, "minFontSize must be between 0 and fontSize")
+	}
+	if f.LineHeight < 0 {
+		v.fail(path, "lineHeight must not be negative")
+	}
+	if f.Align != "" && f.Align != "L" && f.Align != "C" && f.Align != "R" {
+		v.fail(path, "align must be L, C or R")
+	}
+	v.color(path, f.Color)
+}
+
+// columns checks that a list field's columns fit across the page
+func (v *layoutValidator) columns(path string, f LayoutField) {
+	if f.Columns < 0 || f.ColumnGap < 0 {
+		v.fail(path, "columns and columnGap must not be negative")
+		return
+	}
+	if f.Columns > 1 {
+		if right := f.X + f.columnX(f.Columns-1) + f.Width; right > pageWidth {
+			v.fail(path, "the last column ends at %.1f, past the page width %.1f", right, pageWidth)
+		}
+	}
+}
+
+// list checks that a list field's items, and the "more" line below them, end on the page
+func (v *layoutValidator) list(path string, f LayoutField) {
+	if f.LineHeight <= 0 {
+		v.fail(path, "lineHeight must be positive")
+		return
+	}
+	if bottom := f.listBox().Y + f.listBox().Height; bottom > pageHeight {
+		v.fail(path, "the list ends at %.1f with its \"more\" line, past the page height %.1f", bottom, pageHeight)
+	}
+}
+
+// noOverlap rejects fields whose boxes overlap on the same page, in path order
+func (v *layoutValidator) noOverlap(fields map[string]LayoutField) {
+	paths := make([]string, 0, len(fields))
+	for path := range fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for i, a := range paths {
+		for _, b := range paths[i+1:] {
+			if fields[a].overlaps(fields[b]) {
+				v.fail(a, "overlaps %s", b)
+			}
+		}
+	}
+}
+
+// fieldTemplate parses a field's optional text template against the data it runs on
+func (v *layoutValidator) fieldTemplate(path string, dataType reflect.Type, f *LayoutField) {
+	f.tmpl = v.template(path+".text", dataType, f.Text)
+}
+
+// noText rejects a text template on a field that does not print a single line
+func (v *layoutValidator) noText(path string, f LayoutField) {
+	if f.Text != "" {
+		v.fail(path, "text templates are not supported on this field")
+	}
+}
+
+// color checks each channel is 0-255
+func (v *layoutValidator) color(path string, c LayoutColor) {
+	for _, channel := range []int{c.R, c.G, c.B} {
+		if channel < 0 || channel > 255 {
+			v.fail(path, "color channels must be 0-255")
+			return
+		}
+	}
+}
+
+// table checks a table has the expected columns and its header and first row fit the page
+func (v *layoutValidator) table(path string, t LayoutTable, columns int) {
+	if len(t.Columns) != columns {
+		v.fail(path, "must have %d columns, got %d", columns, len(t.Columns))
+	}
+	if t.RowHeight <= 0 || t.FontSize <= 0 {
+		v.fail(path, "rowHeight and fontSize must be positive")
+	}
+	if t.HeaderHeight < 0 || t.HeaderFontSize < 0 {
+		v.fail(path, "headerHeight and headerFontSize must not be negative")
+	}
+	if t.X < 0 || t.Y < 0 {
+		v.fail(path, "x and y must not be negative")
+	}
+	if t.Y+t.headerHeight()+t.RowHeight > tableBottom {
+		v.fail(path, "y leaves no room for rows above the footer at %.1f", float64(tableBottom))
+	}
+
+	width := 0.0
+	for i, column := range t.Columns {
+		if column.Width <= 0 {
+			v.fail(fmt.Sprintf("%s.columns[%d]", path, i), "width must be positive")
+		}
+		if column.Align != "L" && column.Align != "C" && column.Align != "R" {
+			v.fail(fmt.Sprintf("%s.columns[%d]", path, i), "align must be L, C or R")
+		}
+		width += column.Width
+	}
+	if t.X+width > pageWidth {
+		v.fail(path, "x + column widths (%.1f) is past the page width %.1f", t.X+width, pageWidth)
+	}
+}
+
+// fontNames lists the fonts a layout may use, for error messages
+func fontNames() string {
+	return "AcuminMedium, AcuminSemibold, AcuminBold"
+}
+
+// setText selects the field's font, size and color
+func (f LayoutField) setText(pdf *gofpdf.Fpdf, fontSize float64) {
+	pdf.SetFont(f.Font, "", fontSize)
+	pdf.SetTextColor(f.Color.R, f.Color.G, f.Color.B)
+}
+
+// cell writes a single line of text into the field's box
+func (f LayoutField) cell(pdf *gofpdf.Fpdf, text string) {
+	f.setText(pdf, f.FontSize)
+	pdf.SetXY(f.X, f.Y)
+	pdf.CellFormat(f.Width, f.Height, text, "", 0, f.align(), false, 0, "")
+}
+
+// align returns the field's alignment, L when unset
+func (f LayoutField) align() string {
+	if f.Align == "" {
+		return "L"
+	}
+	return f.Align
+}
+
+// perColumn is how many of a list field's MaxItems go in each column
+func (f LayoutField) perColumn() int {
+	if f.Columns <= 1 {
+		return f.MaxItems
+	}
+	return (f.MaxItems + f.Columns - 1) / f.Columns
+}
+
+// columnX is the offset of a list field's column from X
+func (f LayoutField) columnX(column int) float64 {
+	return float64(column) * (f.Width + f.ColumnGap)
+}
+
+// listItem positions item i of a list field. Items fill each column in turn; anything
+// past MaxItems, such as a "more" line, goes below the last column.
+func (f LayoutField) listItem(i int) (x, y float64) {
+	perColumn := f.perColumn()
+	column := 0
+	if perColumn > 0 && f.Columns > 1 {
+		column = i / perColumn
+		if column > f.Columns-1 {
+			column = f.Columns - 1
+		}
+	}
+	row := i - column*perColumn
+	return f.X + f.columnX(column), f.Y + float64(row)*f.LineHeight
+}
+
+// listBox is the area a list field draws in: every column, down to the "more" line
+func (f LayoutField) listBox() LayoutField {
+	columns := max(f.Columns, 1)
+	_, y := f.listItem(f.MaxItems)
+	f.Width = f.columnX(columns-1) + f.Width
+	f.Height = y + f.LineHeight - f.Y
+	return f
+}
+
+// overlaps reports whether two fields' boxes share any area; boxes that only touch do not
+func (f LayoutField) overlaps(other LayoutField) bool {
+	return f.X < other.X+other.Width && other.X < f.X+f.Width &&
+		f.Y < other.Y+other.Height && other.Y < f.Y+f.Height
+}
+
+// headerHeight is the height of the header row
+func (t LayoutTable) headerHeight() float64 {
+	if t.HeaderHeight > 0 {
+		return t.HeaderHeight
+	}
+	return t.RowHeight
+}
+
+// headers returns the column headers
+func (t LayoutTable) headers() []string {
+	headers := make([]string, len(t.Columns))
+	for i, column := range t.Columns {
+		headers[i] = column.Header
+	}
+	return headers
+}
+
+// widths returns the column widths
+func (t LayoutTable) widths() []float64 {
+	widths := make([]float64, len(t.Columns))
+	for i, column := range t.Columns {
+		widths[i] = column.Width
+	}
+	return widths
+}
+
+// aligns returns the column alignments
+func (t LayoutTable) aligns() []string {
+	aligns := make([]string, len(t.Columns))
+	for i, column := range t.Columns {
+		aligns[i] = column.Align
+	}
+	return aligns
+}
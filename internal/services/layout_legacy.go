@@ -0,0 +1,232 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// legacyLayout is the schema template-config.json was first written in, for a
+// 25-Paperwork.pdf template. LoadLayout still reads it so a designer's file keeps working:
+// each value is moved to the matching field of Layout, and values with nothing to draw
+// them (such as instagram or roundEaselBadge) are listed in Layout.Unused.
+type legacyLayout struct {
+	ArtistPage struct {
+		Template string `json:"template"`
+		Fields   struct {
+			ArtistName        *legacyField    `json:"artistName"`
+			EventHistory      *legacyHistory  `json:"eventHistory"`
+			EventHistoryTitle json.RawMessage `json:"eventHistoryTitle"`
+			Instagram         json.RawMessage `json:"instagram"`
+			Location          json.RawMessage `json:"location"`
+			QRCode            *legacyField    `json:"qrCode"`
+			RoundEaselBadge   json.RawMessage `json:"roundEaselBadge"`
+		} `json:"fields"`
+	} `json:"artistPage"`
+	AuctionPage struct {
+		Template   string `json:"template"`
+		PageNumber int    `json:"pageNumber"`
+		Fields     struct {
+			AuctionTable *legacyTable `json:"auctionTable"`
+			Title        *legacyField `json:"title"`
+		} `json:"fields"`
+	} `json:"auctionPage"`
+}
+
+// legacyField is a field of the original schema. Left out values keep the default layout's.
+type legacyField struct {
+	X         *float64     `json:"x"`
+	Y         *float64     `json:"y"`
+	Width     *float64     `json:"width"`
+	Height    *float64     `json:"height"`
+	FontSize  *float64     `json:"fontSize"`
+	FontStyle string       `json:"fontStyle"`
+	Color     *LayoutColor `json:"color"`
+	Text      *string      `json:"text"`
+	// Align is left, center or right of x
+	Align string `json:"align"`
+}
+
+// legacyHistory is the original event history field, a grid of itemsPerColumn rows by
+// maxColumns columns
+type legacyHistory struct {
+	X              *float64 `json:"x"`
+	Y              *float64 `json:"y"`
+	Width          *float64 `json:"width"`
+	Height         *float64 `json:"height"`
+	FontSize       *float64 `json:"fontSize"`
+	LineHeight     *float64 `json:"lineHeight"`
+	ColumnWidth    *float64 `json:"columnWidth"`
+	ColumnGap      *float64 `json:"columnGap"`
+	ItemsPerColumn *int     `json:"itemsPerColumn"`
+	MaxColumns     *int     `json:"maxColumns"`
+	SubFontSize    *float64 `json:"subFontSize"`
+}
+
+// legacyTable is the original auction table. Width is the sum of the column widths.
+type legacyTable struct {
+	X              *float64 `json:"x"`
+	Y              *float64 `json:"y"`
+	Width          *float64 `json:"width"`
+	RowHeight      *float64 `json:"rowHeight"`
+	FontSize       *float64 `json:"fontSize"`
+	HeaderHeight   *float64 `json:"headerHeight"`
+	HeaderFontSize *float64 `json:"headerFontSize"`
+	Columns        []struct {
+		Header string  `json:"header"`
+		Width  float64 `json:"width"`
+	} `json:"columns"`
+}
+
+// parseLegacyLayout reads a config file in the original schema, rejecting unknown keys
+func parseLegacyLayout(data []byte) (*legacyLayout, error) {
+	var legacy legacyLayout
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&legacy); err != nil {
+		return nil, err
+	}
+	return &legacy, nil
+}
+
+// apply moves the legacy values onto layout, value for value
+func (l *legacyLayout) apply(layout *Layout) error {
+	var errs []error
+	unused := func(path string, present bool) {
+		if present {
+			layout.Unused = append(layout.Unused, path)
+		}
+	}
+
+	artist := &layout.ArtistPage.Fields
+	if f := l.ArtistPage.Fields.ArtistName; f != nil {
+		errs = append(errs, f.applyTo("artistPage.fields.artistName", &artist.ArtistName))
+		// The name shrinks to fit from the designer's size; a size below the default
+		// minimum is not shrunk at all
+		if artist.ArtistName.MinFontSize > artist.ArtistName.FontSize {
+			artist.ArtistName.MinFontSize = artist.ArtistName.FontSize
+		}
+	}
+	if f := l.ArtistPage.Fields.QRCode; f != nil {
+		errs = append(errs, f.applyTo("artistPage.fields.qrCode", &artist.QRCode))
+	}
+	if h := l.ArtistPage.Fields.EventHistory; h != nil {
+		h.applyTo(&artist.EventHistory)
+		unused("artistPage.fields.eventHistory.subFontSize", h.SubFontSize != nil)
+	}
+	unused("artistPage.fields.eventHistoryTitle", l.ArtistPage.Fields.EventHistoryTitle != nil)
+	unused("artistPage.fields.instagram", l.ArtistPage.Fields.Instagram != nil)
+	unused("artistPage.fields.location", l.ArtistPage.Fields.Location != nil)
+	unused("artistPage.fields.roundEaselBadge", l.ArtistPage.Fields.RoundEaselBadge != nil)
+	unused("artistPage.template", l.ArtistPage.Template != "")
+
+	auction := &layout.AuctionPage.Fields
+	if f := l.AuctionPage.Fields.Title; f != nil {
+		errs = append(errs, f.applyTo("auctionPage.fields.title", &auction.Title))
+	}
+	if t := l.AuctionPage.Fields.AuctionTable; t != nil {
+		t.applyTo(&auction.Table)
+	}
+	unused("auctionPage.template", l.AuctionPage.Template != "")
+	unused("auctionPage.pageNumber", l.AuctionPage.PageNumber != 0)
+
+	return errors.Join(errs...)
+}
+
+// applyTo moves a legacy field's values onto f
+func (l *legacyField) applyTo(path string, f *LayoutField) error {
+	setFloat(&f.X, l.X)
+	setFloat(&f.Y, l.Y)
+	setFloat(&f.Width, l.Width)
+	setFloat(&f.Height, l.Height)
+	setFloat(&f.FontSize, l.FontSize)
+	if l.Color != nil {
+		f.Color = *l.Color
+	}
+	if l.Text != nil {
+		f.Text = *l.Text
+	}
+
+	switch l.FontStyle {
+	case "":
+	case "bold":
+		f.Font = "AcuminBold"
+	case "normal", "regular":
+		f.Font = "AcuminMedium"
+	default:
+		return fmt.Errorf("%s: fontStyle must be bold or normal", path)
+	}
+
+	// A centered or right-aligned field without a width is anchored at x and may run to
+	// the nearer page edge
+	switch l.Align {
+	case "", "left":
+	case "center":
+		f.Align = "C"
+		if l.Width == nil {
+			f.Width = 2 * min(f.X, pageWidth-f.X)
+			f.X -= f.Width / 2
+		}
+	case "right":
+		f.Align = "R"
+		if l.Width == nil {
+			f.Width = f.X
+			f.X = 0
+		}
+	default:
+		return fmt.Errorf("%s: align must be left, center or right", path)
+	}
+	return nil
+}
+
+// applyTo moves the legacy event history grid onto f as a list field with columns
+func (l *legacyHistory) applyTo(f *LayoutField) {
+	setFloat(&f.X, l.X)
+	setFloat(&f.Y, l.Y)
+	setFloat(&f.Height, l.Height)
+	setFloat(&f.FontSize, l.FontSize)
+	setFloat(&f.LineHeight, l.LineHeight)
+	setFloat(&f.ColumnGap, l.ColumnGap)
+	// Width was the whole grid; each column is columnWidth wide
+	setFloat(&f.Width, l.Width)
+	setFloat(&f.Width, l.ColumnWidth)
+
+	columns := 1
+	if l.MaxColumns != nil {
+		columns = *l.MaxColumns
+		f.Columns = columns
+	}
+	if l.ItemsPerColumn != nil {
+		f.MaxItems = *l.ItemsPerColumn * columns
+	}
+}
+
+// applyTo moves the legacy auction table onto t. The original schema has no column
+// alignment, so each column keeps the default table's.
+func (l *legacyTable) applyTo(t *LayoutTable) {
+	setFloat(&t.X, l.X)
+	setFloat(&t.Y, l.Y)
+	setFloat(&t.RowHeight, l.RowHeight)
+	setFloat(&t.FontSize, l.FontSize)
+	setFloat(&t.HeaderHeight, l.HeaderHeight)
+	setFloat(&t.HeaderFontSize, l.HeaderFontSize)
+
+	if l.Columns != nil {
+		defaults := t.Columns
+		t.Columns = make([]LayoutColumn, len(l.Columns))
+		for i, column := range l.Columns {
+			t.Columns[i] = LayoutColumn{Header: column.Header, Width: column.Width, Align: "L"}
+			if i < len(defaults) {
+				t.Columns[i].Align = defaults[i].Align
+			}
+		}
+	}
+}
+
+// setFloat copies a legacy value that was given
+func setFloat(dst *float64, src *float64) {
+	if src != nil {
+		*dst = *src
+	}
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeLayout writes a layout config to a temporary file and returns its path
+func writeLayout(t *testing.T, config string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "template-config.json")
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDefaultLayoutMatchesHardCodedGeometry(t *testing.T) {
+	layout := DefaultLayout()
+	if err := layout.Validate(); err != nil {
+		t.Fatalf("default layout is invalid: %v", err)
+	}
+
+	// The positions pdf_service.go drew at before the layout was configurable, e.g.
+	// qrY = midPoint + 45 with midPoint = 107.95
+	artist := layout.ArtistPage.Fields
+	list := layout.ArtistListPage.Fields
+	auction := layout.AuctionPage.Fields
+	tests := []struct {
+		name string
+		got  []float64
+		want []float64
+	}{
+		{"qrCode x, y, size", []float64{artist.QRCode.X, artist.QRCode.Y, artist.QRCode.Width, artist.QRCode.Height}, []float64{20, 152.95, 42, 42}},
+		{"artistName x, y, width, size, min size", []float64{artist.ArtistName.X, artist.ArtistName.Y, artist.ArtistName.Width, artist.ArtistName.FontSize, artist.ArtistName.MinFontSize}, []float64{70, 155.95, 279.4 - 70 - 20, 49, 20}},
+		{"eventName x, y, size", []float64{artist.EventName.X, artist.EventName.Y, artist.EventName.FontSize}, []float64{70, 176.95, 18}},
+		{"roundEasel x, y, size", []float64{artist.RoundEasel.X, artist.RoundEasel.Y, artist.RoundEasel.FontSize}, []float64{70, 184.95, 21}},
+		{"eventHistory x, y, width, size, line, items", []float64{artist.EventHistory.X, artist.EventHistory.Y, artist.EventHistory.Width, artist.EventHistory.FontSize, artist.EventHistory.LineHeight, float64(artist.EventHistory.MaxItems)}, []float64{20, 10.05, 115, 12, 6, 20}},
+		{"bio x, y, width, size", []float64{artist.Bio.X, artist.Bio.Y, artist.Bio.Width, artist.Bio.FontSize}, []float64{20 + 115 + 10, 10.05, 279.4 - 145 - 20, 14}},
+		{"artist list title x, y, size", []float64{list.Title.X, list.Title.Y, list.Title.FontSize}, []float64{20, 20, 24}},
+		{"artist list table x, y, row, size", []float64{list.Table.X, list.Table.Y, list.Table.RowHeight, list.Table.FontSize}, []float64{20, 40, 8, 10}},
+		{"artist list columns", list.Table.widths(), []float64{40, 130}},
+		{"auction title x, y, size", []float64{auction.Title.X, auction.Title.Y, auction.Title.FontSize}, []float64{20, 20, 20}},
+		{"auction table x, y, row, size", []float64{auction.Table.X, auction.Table.Y, auction.Table.RowHeight, auction.Table.FontSize}, []float64{20, 40, 8, 9}},
+		{"auction columns", auction.Table.widths(), []float64{40, 60, 20, 25, 60, 35}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	for _, f := range []LayoutField{artist.ArtistName, list.Title, auction.Title} {
+		if f.Font != "AcuminBold" {
+			t.Errorf("title font = %q, want AcuminBold", f.Font)
+		}
+	}
+}
+
+func TestShippedLayoutIsDefault(t *testing.T) {
+	// Deployments print the built-in layout until a designer changes the shipped file
+	layout, err := LoadLayout("../../templates/pdf/configs/template-config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(layout, DefaultLayout()) {
+		t.Errorf("shipped layout differs from DefaultLayout:\n%+v\n%+v", layout, DefaultLayout())
+	}
+}
+
+func TestLoadLayoutReadsOriginalSchema(t *testing.T) {
+	// Each value of the original designer schema moves to its field
+	layout, err := LoadLayout(writeLayout(t, `{
+		"artistPage": {
+			"template": "25-Paperwork.pdf",
+			"fields": {
+				"artistName": {"x": 70, "y": 150, "width": 150, "height": 12, "fontSize": 36, "fontStyle": "bold", "color": {"r": 41, "g": 128, "b": 185}},
+				"qrCode": {"x": 20, "y": 150, "width": 45, "height": 45},
+				"eventHistory": {"x": 20, "y": 10, "width": 115, "columnWidth": 55, "columnGap": 5, "itemsPerColumn": 5, "maxColumns": 2, "fontSize": 8, "subFontSize": 7, "lineHeight": 10},
+				"instagram": {"x": 100, "y": 110}
+			}
+		},
+		"auctionPage": {
+			"pageNumber": 2,
+			"fields": {
+				"title": {"align": "center", "fontSize": 24, "fontStyle": "bold", "text": "AUCTION & BIDDING STATUS", "x": 139.5, "y": 40},
+				"auctionTable": {"x": 30, "y": 70, "rowHeight": 8, "fontSize": 10, "headerHeight": 10, "headerFontSize": 11, "width": 219,
+					"columns": [{"header": "ID", "width": 35}, {"header": "Artist Name", "width": 60}, {"header": "# Bids", "width": 20},
+						{"header": "Top Bid", "width": 25}, {"header": "Bidder Info", "width": 60}, {"header": "Payment", "width": 19}]}
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	artist := layout.ArtistPage.Fields
+	auction := layout.AuctionPage.Fields
+
+	name := artist.ArtistName
+	if name.X != 70 || name.Y != 150 || name.Width != 150 || name.Height != 12 || name.FontSize != 36 ||
+		name.Font != "AcuminBold" || name.Color != (LayoutColor{R: 41, G: 128, B: 185}) {
+		t.Errorf("artistName = %+v", name)
+	}
+	if qr := artist.QRCode; qr.X != 20 || qr.Y != 150 || qr.Width != 45 || qr.Height != 45 {
+		t.Errorf("qrCode = %+v", qr)
+	}
+	history := artist.EventHistory
+	if history.X != 20 || history.Y != 10 || history.Width != 55 || history.ColumnGap != 5 || history.Columns != 2 ||
+		history.MaxItems != 10 || history.FontSize != 8 || history.LineHeight != 10 {
+		t.Errorf("eventHistory = %+v", history)
+	}
+
+	// A centered title is centered on x
+	title := auction.Title
+	if title.X+title.Width/2 != 139.5 || title.Y != 40 || title.Align != "C" || title.FontSize != 24 || title.Text != "AUCTION & BIDDING STATUS" {
+		t.Errorf("auction title = %+v", title)
+	}
+	table := auction.Table
+	if table.X != 30 || table.Y != 70 || table.RowHeight != 8 || table.FontSize != 10 || table.HeaderHeight != 10 || table.HeaderFontSize != 11 {
+		t.Errorf("auction table = %+v", table)
+	}
+	if got := table.headers(); !reflect.DeepEqual(got, []string{"ID", "Artist Name", "# Bids", "Top Bid", "Bidder Info", "Payment"}) {
+		t.Errorf("auction headers = %q", got)
+	}
+	if got := table.widths(); !reflect.DeepEqual(got, []float64{35, 60, 20, 25, 60, 19}) {
+		t.Errorf("auction widths = %v", got)
+	}
+
+	// Fields the file has no value for keep the defaults
+	defaults := DefaultLayout()
+	if !reflect.DeepEqual(artist.Bio, defaults.ArtistPage.Fields.Bio) || !reflect.DeepEqual(layout.ArtistListPage, defaults.ArtistListPage) {
+		t.Error("fields missing from the config lost their defaults")
+	}
+
+	wantUnused := []string{
+		"artistPage.fields.eventHistory.subFontSize",
+		"artistPage.fields.instagram",
+		"artistPage.template",
+		"auctionPage.pageNumber",
+	}
+	if !reflect.DeepEqual(layout.Unused, wantUnused) {
+		t.Errorf("Unused = %q, want %q", layout.Unused, wantUnused)
+	}
+}
+
+func TestLoadLayoutRejectsDesignerDraft(t *testing.T) {
+	// The designer's first file, for a different page design, puts fields on top of each other
+	_, err := LoadLayout("../../templates/pdf/configs/legacy/template-config.json")
+	if err == nil {
+		t.Fatal("overlapping designer config loaded")
+	}
+	for _, want := range []string{
+		"artistPage.fields.artistName: overlaps artistPage.fields.bio",
+		"artistPage.fields.eventHistory: overlaps artistPage.fields.eventName",
+		"artistPage.fields.eventHistory: overlaps artistPage.fields.roundEasel",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoadLayout(t *testing.T) {
+	// A partial file changes only what it names
+	layout, err := LoadLayout(writeLayout(t, `{"artistPage": {"fields": {"qrCode": {"x": 25}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qr := layout.ArtistPage.Fields.QRCode; qr.X != 25 || qr.Width != 42 {
+		t.Errorf("qrCode = %+v, want x 25 with the default size", qr)
+	}
+	if len(layout.Unused) != 0 {
+		t.Errorf("Unused = %q, want none", layout.Unused)
+	}
+
+	tests := []struct {
+		name   string
+		config string
+		want   []string
+	}{
+		{"typo", `{"artistPage": {"fields": {"qrCod": {"x": 30}}}}`, []string{`unknown field "qrCod"`}},
+		{"every problem", `{"artistPage": {"fields": {"qrCode": {"x": 260}, "artistName": {"font": "Arial"}}},
+			"auctionPage": {"fields": {"table": {"columns": [{"width": 10, "align": "L"}]}}}}`,
+			[]string{"artistPage.fields.qrCode: x + width", `artistPage.fields.artistName: unknown font "Arial"`, "auctionPage.fields.table: must have 6 columns"}},
+		{"history columns", `{"artistPage": {"fields": {"eventHistory": {"x": 20, "width": 115, "columns": 3, "columnGap": 10}}}}`,
+			[]string{"artistPage.fields.eventHistory: the last column ends at 385.0"}},
+		{"overlap", `{"artistPage": {"fields": {"qrCode": {"x": 30}, "artistName": {"y": 140}}}}`,
+			[]string{"artistPage.fields.artistName: overlaps artistPage.fields.bio", "artistPage.fields.eventName: overlaps artistPage.fields.qrCode"}},
+		{"list past the page", `{"artistPage": {"fields": {"eventHistory": {"maxItems": 40}}}}`,
+			[]string{`artistPage.fields.eventHistory: the list ends at 256.1 with its "more" line, past the page height 215.9`}},
+		{"list lineHeight", `{"artistPage": {"fields": {"eventHistory": {"lineHeight": 0}}}}`,
+			[]string{"artistPage.fields.eventHistory: lineHeight must be positive"}},
+		{"legacy font style", `{"artistPage": {"fields": {"artistName": {"fontStyle": "italic"}}}}`,
+			[]string{"artistPage.fields.artistName: fontStyle must be bold or normal"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadLayout(writeLayout(t, tt.config))
+			if err == nil {
+				t.Fatal("config accepted")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestListItemColumns(t *testing.T) {
+	f := LayoutField{X: 20, Y: 150, Width: 115, ColumnGap: 10, Columns: 2, MaxItems: 10, LineHeight: 10}
+	tests := []struct {
+		item int
+		x, y float64
+	}{
+		{0, 20, 150},
+		{4, 20, 190},
+		{5, 145, 150},
+		{9, 145, 190},
+		// The "more" line goes below the last column
+		{10, 145, 200},
+	}
+	for _, tt := range tests {
+		if x, y := f.listItem(tt.item); x != tt.x || y != tt.y {
+			t.Errorf("listItem(%d) = %v, %v, want %v, %v", tt.item, x, y, tt.x, tt.y)
+		}
+	}
+
+	// A single column runs straight down, as before columns existed
+	f.Columns = 0
+	if x, y := f.listItem(12); x != 20 || y != 270 {
+		t.Errorf("single column listItem(12) = %v, %v", x, y)
+	}
+}
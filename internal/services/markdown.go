@@ -0,0 +1,182 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+)
+
+// richStyle is the inline formatting of a piece of bio text
+type richStyle struct {
+	Bold   bool
+	Italic bool
+	Link   string
+}
+
+// richFragment is a run of text in one style
+type richFragment struct {
+	Text  string
+	Style richStyle
+}
+
+// richBreak is a break in the text flow before a word
+type richBreak int
+
+const (
+	breakNone richBreak = iota
+	breakLine
+	breakParagraph
+)
+
+// richWord is one space-separated word of parsed bio text. A word can mix styles,
+// e.g. "(new)," where only "new" is bold.
+type richWord struct {
+	Parts []richFragment
+	// Break starts the word on a new line or paragraph
+	Break richBreak
+}
+
+// text returns the word without formatting
+func (w richWord) text() string {
+	var b strings.Builder
+	for _, part := range w.Parts {
+		b.WriteString(part.Text)
+	}
+	return b.String()
+}
+
+// markdownInlinePattern matches the supported inline syntax: links, bold and italic.
+// Leftmost-first alternation makes ** win over *. Emphasis must open and close next to
+// text, so spaced or stray markers such as "** no **" are printed as they are.
+var markdownInlinePattern = regexp.MustCompile(
+	`\[([^\]]+)\]\(([^)\s]+)\)` + // 1, 2: [text](url)
+		`|\*\*(\S(?:.*?\S)?)\*\*` + // 3: **bold**
+		`|__(\S(?:.*?\S)?)__` + // 4: __bold__
+		`|\*([^*\s](?:(?:[^*]|\*\*[^*]+\*\*)*?[^*\s])?)\*` + // 5: *italic*, which may hold **bold**
+		`|_([^_\s](?:[^_]*?[^_\s])?)_`) // 6: _italic_
+
+// parseMarkdown parses the light Markdown subset used in bios: paragraphs (blank lines),
+// line breaks, **bold**, *italic* and [links](url). Anything else is kept as literal text.
+func parseMarkdown(text string) []richWord {
+	text = printableText(text)
+
+	words := []richWord{}
+	pending := breakNone
+	for p, paragraph := range strings.Split(text, "\n\n") {
+		if p > 0 {
+			pending = breakParagraph
+		}
+		for l, line := range strings.Split(paragraph, "\n") {
+			if l > 0 && pending == breakNone {
+				pending = breakLine
+			}
+
+			runs := []richFragment{}
+			appendInline(&runs, strings.Join(strings.Fields(line), " "), richStyle{})
+			lineWords := splitWords(runs)
+			if len(lineWords) == 0 {
+				continue
+			}
+			if len(words) > 0 {
+				lineWords[0].Break = pending
+			}
+			pending = breakNone
+			words = append(words, lineWords...)
+		}
+	}
+	return words
+}
+
+// appendInline splits a line into styled runs, applying nested inline formatting
+func appendInline(runs *[]richFragment, text string, style richStyle) {
+	for text != "" {
+		m := markdownInlinePattern.FindStringSubmatchIndex(text)
+		if m == nil {
+			*runs = append(*runs, richFragment{Text: text, Style: style})
+			return
+		}
+
+		// Underscores inside a word (snake_case, @jane_doe_art) are not emphasis
+		if text[m[0]] == '_' && (isWordByte(text, m[0]-1) || isWordByte(text, m[1])) {
+			*runs = append(*runs, richFragment{Text: text[:m[0]+1], Style: style})
+			text = text[m[0]+1:]
+			continue
+		}
+
+		if m[0] > 0 {
+			*runs = append(*runs, richFragment{Text: text[:m[0]], Style: style})
+		}
+
+		inner := style
+		var content string
+		switch {
+		case m[2] >= 0:
+			inner.Link = text[m[4]:m[5]]
+			content = text[m[2]:m[3]]
+		case m[6] >= 0:
+			inner.Bold = true
+			content = text[m[6]:m[7]]
+		case m[8] >= 0:
+			inner.Bold = true
+			content = text[m[8]:m[9]]
+		case m[10] >= 0:
+			inner.Italic = true
+			content = text[m[10]:m[11]]
+		default:
+			inner.Italic = true
+			content = text[m[12]:m[13]]
+		}
+		appendInline(runs, content, inner)
+
+		text = text[m[1]:]
+	}
+}
+
+// splitWords breaks styled runs into words at spaces, merging neighbouring pieces of one style
+func splitWords(runs []richFragment) []richWord {
+	words := []richWord{}
+	current := richWord{}
+	flush := func() {
+		if len(current.Parts) > 0 {
+			words = append(words, current)
+			current = richWord{}
+		}
+	}
+
+	for _, run := range runs {
+		for i, piece := range strings.Split(run.Text, " ") {
+			if i > 0 {
+				flush()
+			}
+			if piece == "" {
+				continue
+			}
+			if n := len(current.Parts); n > 0 && current.Parts[n-1].Style == run.Style {
+				current.Parts[n-1].Text += piece
+				continue
+			}
+			current.Parts = append(current.Parts, richFragment{Text: piece, Style: run.Style})
+		}
+	}
+	flush()
+	return words
+}
+
+// isWordByte reports whether the byte at i is a letter or digit; out of range is false
+func isWordByte(text string, i int) bool {
+	if i < 0 || i >= len(text) {
+		return false
+	}
+	c := text[i]
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// printableText cleans text for the PDF fonts, dropping carriage returns and characters
+// beyond the BMP (e.g. emoji), which the Acumin width tables cannot measure
+func printableText(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\r' || r > 0xFFFF {
+			return -1
+		}
+		return r
+	}, cleanString(text))
+}
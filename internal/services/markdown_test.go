@@ -0,0 +1,73 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+// richString writes parsed words back out in a canonical form: <b>, <i> and <a href>
+// around styled fragments, "|" before a line break and "||" before a paragraph
+func richString(words []richWord) string {
+	var b strings.Builder
+	for i, word := range words {
+		switch {
+		case word.Break == breakParagraph:
+			b.WriteString(" || ")
+		case word.Break == breakLine:
+			b.WriteString(" | ")
+		case i > 0:
+			b.WriteString(" ")
+		}
+		for _, part := range word.Parts {
+			text := part.Text
+			if part.Style.Italic {
+				text = "<i>" + text + "</i>"
+			}
+			if part.Style.Bold {
+				text = "<b>" + text + "</b>"
+			}
+			if part.Style.Link != "" {
+				text = `<a href="` + part.Style.Link + `">` + text + "</a>"
+			}
+			b.WriteString(text)
+		}
+	}
+	return b.String()
+}
+
+func TestParseMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain", "Paints  big murals.", "Paints big murals."},
+		{"bold", "A **very bold** move", "A <b>very</b> <b>bold</b> move"},
+		{"bold underscores", "__bold__ move", "<b>bold</b> move"},
+		{"italic", "An *italic* and _another_ word", "An <i>italic</i> and <i>another</i> word"},
+		{"bold inside italic", "*so **very** good*", "<i>so</i> <b><i>very</i></b> <i>good</i>"},
+		{"italic inside bold", "**so *very* good**", "<b>so</b> <b><i>very</i></b> <b>good</b>"},
+		{"mixed styles in one word", "(**new**),", "(<b>new</b>),"},
+		{"link", "See [my work](https://example.com) today", `See <a href="https://example.com">my</a> <a href="https://example.com">work</a> today`},
+		{"bold link text", "[**@jane**](https://instagram.com/jane)", `<a href="https://instagram.com/jane"><b>@jane</b></a>`},
+		{"line break", "One\nTwo", "One | Two"},
+		{"paragraph", "One.\n\nTwo.", "One. || Two."},
+		{"blank lines collapse", "One.\n\n\n\nTwo.", "One. || Two."},
+		{"leading break dropped", "\n\nOne.", "One."},
+		{"unbalanced bold", "**never closed", "**never closed"},
+		{"unbalanced italic", "a * b and *c", "a * b and *c"},
+		{"spaced markers", "** not bold ** and * not italic *", "** not bold ** and * not italic *"},
+		{"underscores inside words", "@jane_doe_art and snake_case_name", "@jane_doe_art and snake_case_name"},
+		{"unclosed link", "[text](no end", "[text](no end"},
+		{"carriage returns and emoji", "One\r\nTwo 🎨", "One | Two"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := richString(parseMarkdown(tt.text)); got != tt.want {
+				t.Errorf("parseMarkdown(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"paperwork-service/internal/models"
+)
+
+// Data warning codes reported by NormalizePaperworkData
+const (
+	WarningDuplicateEasel       = "duplicate_easel"
+	WarningMissingArtistName    = "missing_artist_name"
+	WarningUnmatchedLot         = "unmatched_lot"
+	WarningTotalArtistsMismatch = "total_artists_mismatch"
+	WarningTotalBidsMismatch    = "total_bids_mismatch"
+	WarningMissingRound         = "missing_round"
+)
+
+// DataWarning describes a problem in the event data that may affect the printed paperwork
+type DataWarning struct {
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	RoundNumber int    `json:"round_number,omitempty"`
+	EaselNumber int    `json:"easel_number,omitempty"`
+	EntryID     int    `json:"entry_id,omitempty"`
+}
+
+// NormalizedPaperworkData is the paperwork data as the PDF sees it, plus any data warnings
+type NormalizedPaperworkData struct {
+	PaperworkData
+	Warnings []DataWarning `json:"warnings"`
+}
+
+// NormalizePaperworkData resolves artist display names, sorts artists and lots by
+// round and easel, joins lots to their artists, and reports inconsistencies.
+// The data is modified in place.
+func NormalizePaperworkData(data *PaperworkData) []DataWarning {
+	warnings := []DataWarning{}
+
+	// Resolve display names so every consumer prints the same name
+	for i := range data.Artists {
+		artist := &data.Artists[i]
+		artist.DisplayName = resolveArtistName(*artist)
+		if artist.DisplayName == "" {
+			warnings = append(warnings, DataWarning{
+				Code:        WarningMissingArtistName,
+				Message:     fmt.Sprintf("Artist at round %d, easel %d has no name", artist.RoundNumber, artist.EaselNumber),
+				RoundNumber: artist.RoundNumber,
+				EaselNumber: artist.EaselNumber,
+				EntryID:     artist.EntryID,
+			})
+		}
+		if artist.RoundNumber == 0 && artist.Status != "confirmed-only" {
+			warnings = append(warnings, DataWarning{
+				Code:        WarningMissingRound,
+				Message:     fmt.Sprintf("Artist %q is not assigned to a round", artist.DisplayName),
+				EaselNumber: artist.EaselNumber,
+				EntryID:     artist.EntryID,
+			})
+		}
+	}
+
+	// Sort by round then easel; artists without a round go last
+	sort.SliceStable(data.Artists, func(i, j int) bool {
+		return easelLess(data.Artists[i].RoundNumber, data.Artists[i].EaselNumber,
+			data.Artists[j].RoundNumber, data.Artists[j].EaselNumber)
+	})
+	sort.SliceStable(data.AuctionLots, func(i, j int) bool {
+		return easelLess(data.AuctionLots[i].Round, data.AuctionLots[i].EaselNumber,
+			data.AuctionLots[j].Round, data.AuctionLots[j].EaselNumber)
+	})
+
+	// Index ready artists by round/easel, flagging duplicates
+	artistsByEasel := make(map[string]models.EventArtist)
+	for _, artist := range data.Artists {
+		if artist.Status == "confirmed-only" {
+			continue
+		}
+		key := easelKey(artist.RoundNumber, artist.EaselNumber)
+		if existing, ok := artistsByEasel[key]; ok {
+			warnings = append(warnings, DataWarning{
+				Code: WarningDuplicateEasel,
+				Message: fmt.Sprintf("Round %d, easel %d is assigned to both %q and %q",
+					artist.RoundNumber, artist.EaselNumber, existing.DisplayName, artist.DisplayName),
+				RoundNumber: artist.RoundNumber,
+				EaselNumber: artist.EaselNumber,
+				EntryID:     artist.EntryID,
+			})
+			continue
+		}
+		artistsByEasel[key] = artist
+	}
+
+	// Join lots to artists
+	bidCount := 0
+	for i := range data.AuctionLots {
+		lot := &data.AuctionLots[i]
+		if len(lot.AllBids) > 0 {
+			bidCount += len(lot.AllBids)
+		} else {
+			bidCount += lot.BidCount
+		}
+
+		artist, ok := artistsByEasel[easelKey(lot.Round, lot.EaselNumber)]
+		if !ok {
+			warnings = append(warnings, DataWarning{
+				Code:        WarningUnmatchedLot,
+				Message:     fmt.Sprintf("Auction lot at round %d, easel %d has no matching artist", lot.Round, lot.EaselNumber),
+				RoundNumber: lot.Round,
+				EaselNumber: lot.EaselNumber,
+			})
+			continue
+		}
+		if artist.DisplayName != "" {
+			lot.ArtistName = artist.DisplayName
+		}
+	}
+
+	if data.TotalArtists != len(data.Artists) {
+		warnings = append(warnings, DataWarning{
+			Code:    WarningTotalArtistsMismatch,
+			Message: fmt.Sprintf("total_artists is %d but %d artists were returned", data.TotalArtists, len(data.Artists)),
+		})
+	}
+	if data.TotalBids != bidCount {
+		warnings = append(warnings, DataWarning{
+			Code:    WarningTotalBidsMismatch,
+			Message: fmt.Sprintf("total_bids is %d but the auction lots contain %d bids", data.TotalBids, bidCount),
+		})
+	}
+
+	return warnings
+}
+
+// resolveArtistName picks the name to print: display name, then artist name, then first and last name
+func resolveArtistName(artist models.EventArtist) string {
+	if name := strings.TrimSpace(artist.DisplayName); name != "" {
+		return name
+	}
+	if name := strings.TrimSpace(artist.ArtistName); name != "" {
+		return name
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %s", artist.FirstName, artist.LastName))
+}
+
+// easelKey builds the round-easel lookup key used to join artists and lots
+func easelKey(round, easel int) string {
+	return fmt.Sprintf("%d-%d", round, easel)
+}
+
+// easelLess orders by round then easel, placing round 0 (unassigned) last
+func easelLess(roundA, easelA, roundB, easelB int) bool {
+	if roundA != roundB {
+		if roundA == 0 {
+			return false
+		}
+		if roundB == 0 {
+			return true
+		}
+		return roundA < roundB
+	}
+	return easelA < easelB
+}
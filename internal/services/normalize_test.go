@@ -0,0 +1,166 @@
+package services
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"paperwork-service/internal/models"
+)
+
+func TestNormalizePaperworkDataWarnings(t *testing.T) {
+	// artist is a ready artist at round and easel with an artist name
+	artist := func(round, easel int, name string) models.EventArtist {
+		return models.EventArtist{RoundNumber: round, EaselNumber: easel, ArtistName: name}
+	}
+	lot := func(round, easel int, bids int) models.AuctionLot {
+		return models.AuctionLot{Round: round, EaselNumber: easel, AllBids: make([]models.Bid, bids)}
+	}
+
+	tests := []struct {
+		name         string
+		data         PaperworkData
+		wantCodes    []string
+		wantArtists  []string // round-easel name, in the normalized order
+		wantLotNames []string
+	}{
+		{
+			name: "clean",
+			data: PaperworkData{
+				Artists:      []models.EventArtist{artist(1, 2, "Bea"), artist(1, 1, "Ann")},
+				AuctionLots:  []models.AuctionLot{lot(1, 2, 3), lot(1, 1, 1)},
+				TotalArtists: 2, TotalBids: 4,
+			},
+			wantCodes:    []string{},
+			wantArtists:  []string{"1-1 Ann", "1-2 Bea"},
+			wantLotNames: []string{"Ann", "Bea"},
+		},
+		{
+			name: "duplicate easel keeps the first artist for the lot",
+			data: PaperworkData{
+				Artists:      []models.EventArtist{artist(1, 1, "Ann"), artist(1, 1, "Cal")},
+				AuctionLots:  []models.AuctionLot{lot(1, 1, 0)},
+				TotalArtists: 2,
+			},
+			wantCodes:    []string{WarningDuplicateEasel},
+			wantArtists:  []string{"1-1 Ann", "1-1 Cal"},
+			wantLotNames: []string{"Ann"},
+		},
+		{
+			name: "confirmed-only artists are neither duplicates nor missing a round",
+			data: PaperworkData{
+				Artists: []models.EventArtist{artist(1, 1, "Ann"),
+					{RoundNumber: 1, EaselNumber: 1, ArtistName: "Dee", Status: "confirmed-only"},
+					{ArtistName: "Eve", Status: "confirmed-only"}},
+				TotalArtists: 3,
+			},
+			wantCodes:    []string{},
+			wantArtists:  []string{"1-1 Ann", "1-1 Dee", "0-0 Eve"},
+			wantLotNames: []string{},
+		},
+		{
+			name: "missing round sorts last",
+			data: PaperworkData{
+				Artists:      []models.EventArtist{artist(0, 3, "Fay"), artist(2, 1, "Gus")},
+				TotalArtists: 2,
+			},
+			wantCodes:    []string{WarningMissingRound},
+			wantArtists:  []string{"2-1 Gus", "0-3 Fay"},
+			wantLotNames: []string{},
+		},
+		{
+			name: "names fall back to first and last name, then warn",
+			data: PaperworkData{
+				Artists: []models.EventArtist{
+					{RoundNumber: 1, EaselNumber: 1, DisplayName: "  ", FirstName: "Hal", LastName: "Hue"},
+					{RoundNumber: 1, EaselNumber: 2, EntryID: 77},
+				},
+				AuctionLots:  []models.AuctionLot{lot(1, 2, 1)},
+				TotalArtists: 2, TotalBids: 1,
+			},
+			wantCodes:    []string{WarningMissingArtistName},
+			wantArtists:  []string{"1-1 Hal Hue", "1-2 "},
+			wantLotNames: []string{""},
+		},
+		{
+			name: "unmatched lot keeps its own name",
+			data: PaperworkData{
+				Artists:      []models.EventArtist{artist(1, 1, "Ann")},
+				AuctionLots:  []models.AuctionLot{{Round: 3, EaselNumber: 9, ArtistName: "Old Name", BidCount: 2}},
+				TotalArtists: 1, TotalBids: 2,
+			},
+			wantCodes:    []string{WarningUnmatchedLot},
+			wantArtists:  []string{"1-1 Ann"},
+			wantLotNames: []string{"Old Name"},
+		},
+		{
+			name: "totals that disagree with the rows",
+			data: PaperworkData{
+				Artists: []models.EventArtist{artist(1, 1, "Ann")},
+				// BidCount is used only when the lot has no bid list
+				AuctionLots:  []models.AuctionLot{lot(1, 1, 2), {Round: 1, EaselNumber: 1, BidCount: 5}},
+				TotalArtists: 4, TotalBids: 2,
+			},
+			wantCodes:    []string{WarningTotalArtistsMismatch, WarningTotalBidsMismatch},
+			wantArtists:  []string{"1-1 Ann"},
+			wantLotNames: []string{"Ann", "Ann"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := NormalizePaperworkData(&tt.data)
+
+			codes := []string{}
+			for _, w := range warnings {
+				codes = append(codes, w.Code)
+				if w.Message == "" {
+					t.Errorf("%s warning has no message", w.Code)
+				}
+			}
+			if !reflect.DeepEqual(codes, tt.wantCodes) {
+				t.Errorf("codes = %q, want %q", codes, tt.wantCodes)
+			}
+
+			artists := []string{}
+			for _, a := range tt.data.Artists {
+				artists = append(artists, fmt.Sprintf("%d-%d %s", a.RoundNumber, a.EaselNumber, a.DisplayName))
+			}
+			if !reflect.DeepEqual(artists, tt.wantArtists) {
+				t.Errorf("artists = %q, want %q", artists, tt.wantArtists)
+			}
+
+			lotNames := []string{}
+			for _, l := range tt.data.AuctionLots {
+				lotNames = append(lotNames, l.ArtistName)
+			}
+			if !reflect.DeepEqual(lotNames, tt.wantLotNames) {
+				t.Errorf("lot names = %q, want %q", lotNames, tt.wantLotNames)
+			}
+		})
+	}
+}
+
+func TestNormalizeWarningDetails(t *testing.T) {
+	data := &PaperworkData{
+		Artists: []models.EventArtist{
+			{RoundNumber: 2, EaselNumber: 5, ArtistName: "Ann", EntryID: 310},
+			{RoundNumber: 2, EaselNumber: 5, EntryID: 311},
+		},
+		TotalArtists: 2,
+	}
+	warnings := NormalizePaperworkData(data)
+	if len(warnings) != 2 {
+		t.Fatalf("warnings = %+v", warnings)
+	}
+
+	// Each warning says where the problem is so staff can find it
+	missing, duplicate := warnings[0], warnings[1]
+	if missing.Code != WarningMissingArtistName || missing.RoundNumber != 2 || missing.EaselNumber != 5 || missing.EntryID != 311 {
+		t.Errorf("missing name warning = %+v", missing)
+	}
+	if duplicate.Code != WarningDuplicateEasel || duplicate.EntryID != 311 || !strings.Contains(duplicate.Message, `"Ann"`) {
+		t.Errorf("duplicate easel warning = %+v", duplicate)
+	}
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"strconv"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Debug overlay spacing in mm
+const (
+	debugGridMinor = 5
+	debugGridMajor = 10
+)
+
+// debugOverlay draws alignment guides over every page for designers: a millimetre grid and
+// the box and text baselines of each field on the page. Guides are drawn by gofpdf's footer
+// hook as each page is finished, so they sit on top of the background and the content.
+// A nil overlay draws nothing, so renderers can record fields without checking.
+type debugOverlay struct {
+	pdf *gofpdf.Fpdf
+	// section holds fields drawn on every page of the current section; page holds the
+	// section's fields as they were when the current page started, plus fields recorded since
+	section []debugField
+	page    []debugField
+}
+
+// debugField is one guide: a labelled box, with a baseline per line of text
+type debugField struct {
+	label      string
+	box        LayoutField
+	lineHeight float64 // 0 for a single line centred in the box
+	columns    []float64
+}
+
+// newDebugOverlay hooks the overlay into the document's page breaks
+func newDebugOverlay(pdf *gofpdf.Fpdf) *debugOverlay {
+	o := &debugOverlay{pdf: pdf}
+	pdf.SetHeaderFunc(func() {
+		o.page = append([]debugField(nil), o.section...)
+	})
+	pdf.SetFooterFunc(o.draw)
+	return o
+}
+
+// startSection replaces the fields drawn on every page, from the next page on
+func (o *debugOverlay) startSection(fields ...debugField) {
+	if o == nil {
+		return
+	}
+	o.section = fields
+}
+
+// field records a single-line text field or a picture on the current page
+func (o *debugOverlay) field(label string, f LayoutField) {
+	if o == nil {
+		return
+	}
+	o.page = append(o.page, debugField{label: label, box: f})
+}
+
+// lines records a multi-line text field on the current page
+func (o *debugOverlay) lines(label string, f LayoutField, lineHeight float64) {
+	if o == nil {
+		return
+	}
+	o.page = append(o.page, debugField{label: label, box: f, lineHeight: lineHeight})
+}
+
+// table records a table's column boxes for the given number of rows on the current page
+func (o *debugOverlay) table(label string, x, y float64, widths []float64, rowHeight, fontSize float64, rows int) {
+	if o == nil {
+		return
+	}
+	o.page = append(o.page, tableGuide(label, x, y, widths, rowHeight, fontSize, rows))
+}
+
+// tableGuide is the guide for a table's first rows
+func tableGuide(label string, x, y float64, widths []float64, rowHeight, fontSize float64, rows int) debugField {
+	return debugField{
+		label:      label,
+		box:        LayoutField{X: x, Y: y, Width: sumWidths(widths), Height: float64(rows) * rowHeight, FontSize: fontSize},
+		lineHeight: rowHeight,
+		columns:    widths,
+	}
+}
+
+// sectionGuides are the layout fields drawn on every page of a built-in section, labelled
+// with their key in the layout config
+func (l Layout) sectionGuides(section Section, showBidders bool) []debugField {
+	layoutTable := func(label string, t LayoutTable) debugField {
+		return tableGuide(label, t.X, t.Y, t.widths(), t.RowHeight, t.FontSize, 2)
+	}
+
+	switch section {
+	case SectionArtistList:
+		fields := l.ArtistListPage.Fields
+		return []debugField{{label: "title", box: fields.Title}, layoutTable("table", fields.Table)}
+
+	case SectionAuction:
+		fields := l.AuctionPage.Fields
+		if !showBidders {
+			return []debugField{{label: "title", box: fields.Title}, layoutTable("publicTable", fields.PublicTable)}
+		}
+		return []debugField{{label: "title", box: fields.Title}, layoutTable("table", fields.Table)}
+
+	case SectionBios:
+		return []debugField{{label: "title", box: titleField(24)}}
+
+	case SectionBidHistory:
+		return []debugField{{label: "title", box: titleField(20)}}
+
+	case SectionArtistPages:
+		fields := l.ArtistPage.Fields
+		// The history has no height of its own: it runs for maxItems lines plus the "more" line,
+		// split across its columns
+		history := fields.EventHistory
+		history.Height = float64(history.perColumn()+1) * history.LineHeight
+		historyGuide := debugField{label: "eventHistory", box: history, lineHeight: history.LineHeight}
+		if history.Columns > 1 {
+			historyGuide.box.Width = history.columnX(history.Columns-1) + history.Width
+			historyGuide.columns = []float64{history.Width}
+			for column := 1; column < history.Columns; column++ {
+				historyGuide.columns = append(historyGuide.columns, history.ColumnGap, history.Width)
+			}
+		}
+		return []debugField{
+			historyGuide,
+			{label: "bio", box: fields.Bio, lineHeight: fields.Bio.LineHeight},
+			{label: "qrCode", box: fields.QRCode},
+			{label: "artistName", box: fields.ArtistName},
+			{label: "eventName", box: fields.EventName},
+			{label: "roundEasel", box: fields.RoundEasel},
+		}
+	}
+	return nil
+}
+
+// draw finishes the current page with the grid and its field guides
+func (o *debugOverlay) draw() {
+	pdf := o.pdf
+
+	// Grid: light lines every 5mm, darker every 10mm, numbered along the top and left
+	pdf.SetAlpha(0.4, "Normal")
+	pdf.SetFont("Helvetica", "", 5)
+	pdf.SetTextColor(0, 110, 200)
+	for x := 0; float64(x) <= pageWidth; x += debugGridMinor {
+		o.gridLine(x)
+		pdf.Line(float64(x), 0, float64(x), pageHeight)
+		if x%debugGridMajor == 0 && x > 0 {
+			pdf.Text(float64(x)+0.5, 2.5, strconv.Itoa(x))
+		}
+	}
+	for y := 0; float64(y) <= pageHeight; y += debugGridMinor {
+		o.gridLine(y)
+		pdf.Line(0, float64(y), pageWidth, float64(y))
+		if y%debugGridMajor == 0 && y > 0 {
+			pdf.Text(0.5, float64(y)-0.5, strconv.Itoa(y))
+		}
+	}
+
+	// Fields: red boxes with their name, blue baselines
+	pdf.SetAlpha(0.9, "Normal")
+	for _, guide := range o.page {
+		o.drawField(guide)
+	}
+	pdf.SetAlpha(1, "Normal")
+	pdf.SetDashPattern([]float64{}, 0)
+	o.page = nil
+}
+
+// gridLine picks the line style for a grid position
+func (o *debugOverlay) gridLine(mm int) {
+	if mm%debugGridMajor == 0 {
+		o.pdf.SetDrawColor(0, 110, 200)
+		o.pdf.SetLineWidth(0.1)
+	} else {
+		o.pdf.SetDrawColor(120, 180, 230)
+		o.pdf.SetLineWidth(0.05)
+	}
+}
+
+// drawField draws one field's box, label and baselines
+func (o *debugOverlay) drawField(guide debugField) {
+	pdf := o.pdf
+	box := guide.box
+
+	pdf.SetDrawColor(220, 30, 30)
+	pdf.SetLineWidth(0.25)
+	pdf.SetDashPattern([]float64{}, 0)
+	pdf.Rect(box.X, box.Y, box.Width, box.Height, "D")
+	x := box.X
+	for _, width := range guide.columns {
+		x += width
+		pdf.Line(x, box.Y, x, box.Y+box.Height)
+	}
+
+	pdf.SetFont("Helvetica", "", 5)
+	pdf.SetTextColor(220, 30, 30)
+	pdf.Text(box.X+0.5, box.Y-0.5, guide.label)
+
+	// A picture has no baseline
+	if box.FontSize <= 0 {
+		return
+	}
+
+	// gofpdf centres a line of text vertically in its cell, putting the baseline
+	// 0.3 of the font size below the middle
+	pdf.SetDrawColor(30, 60, 220)
+	pdf.SetLineWidth(0.15)
+	pdf.SetDashPattern([]float64{1, 0.6}, 0)
+	drop := 0.3 * box.FontSize * ptToMM
+	if guide.lineHeight <= 0 {
+		baseline := box.Y + box.Height/2 + drop
+		pdf.Line(box.X, baseline, box.X+box.Width, baseline)
+		return
+	}
+	for top := box.Y; top+guide.lineHeight <= box.Y+box.Height+0.01; top += guide.lineHeight {
+		baseline := top + guide.lineHeight/2 + drop
+		pdf.Line(box.X, baseline, box.X+box.Width, baseline)
+	}
+}
@@ -2,10 +2,13 @@ package services
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"image/png"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"paperwork-service/internal/models"
@@ -20,51 +23,179 @@ type PaperworkPDFService struct {
 	logger          *zap.Logger
 	backgroundsPath string
 	fontsPath       string
+	bioPolicy       BioPolicy
+	layout          Layout
+	documents       map[string]*Document
 }
 
-// NewPaperworkPDFService creates a new background-based PDF service
-func NewPaperworkPDFService(logger *zap.Logger, templatesPath string) *PaperworkPDFService {
+// NewPaperworkPDFService creates a new background-based PDF service.
+// bioPolicy picks and cleans the bio printed for each artist; layout positions the page elements
+// of the built-in sections. documents are the declarative documents GenerateDocument can render.
+func NewPaperworkPDFService(logger *zap.Logger, templatesPath string, bioPolicy BioPolicy, layout Layout, documents map[string]*Document) *PaperworkPDFService {
 	return &PaperworkPDFService{
 		logger:          logger,
 		backgroundsPath: filepath.Join(templatesPath, "backgrounds"),
 		fontsPath:       filepath.Join(templatesPath, "fonts"),
+		bioPolicy:       bioPolicy,
+		layout:          layout,
+		documents:       documents,
 	}
 }
 
-// GenerateEventPaperwork generates the PDF with background images
-func (s *PaperworkPDFService) GenerateEventPaperwork(event *models.Event, artists []models.EventArtist, auctionLots []models.AuctionLot) ([]byte, error) {
-	// Create PDF in landscape mode
-	pdf := gofpdf.New("L", "mm", "Letter", "")
-	pdf.SetAutoPageBreak(false, 0)
+// ProgressFunc is notified as each section starts and finishes rendering
+type ProgressFunc func(section Section, done bool)
+
+// RenderOptions selects what goes into an event paperwork PDF
+type RenderOptions struct {
+	// Sections to render, in order; empty selects DefaultSections
+	Sections []Section
+	// Profile controls redaction of contact and payment details; empty is treated as public
+	Profile Profile
+	// Progress is notified as each section starts and finishes (optional)
+	Progress ProgressFunc
+	// BioBudget shortens the bios on the bio summary pages for compact packs (optional)
+	BioBudget BioBudget
+	// Debug draws a millimetre grid and every field's box and baselines over each page, for designers
+	Debug bool
+}
 
-	// Add custom fonts
-	s.addCustomFonts(pdf)
+// BioBudget limits how much of each bio the summary pages print; zero fields mean no limit.
+// Truncated bios end with an ellipsis.
+type BioBudget struct {
+	MaxChars int
+	MaxLines int
+}
 
-	// Add artist list page with background
-	s.addPageWithBackground(pdf, "artist-list-bg.png")
-	s.addArtistListContent(pdf, event.Name, artists)
+// GenerateEventPaperwork generates the full-detail (staff) PDF with background images.
+// Only the requested sections are rendered, in the order given; nil or empty selects DefaultSections.
+func (s *PaperworkPDFService) GenerateEventPaperwork(event *models.Event, artists []models.EventArtist, auctionLots []models.AuctionLot, sections []Section) ([]byte, error) {
+	return s.GenerateEventPaperworkContext(context.Background(), event, artists, auctionLots, RenderOptions{
+		Sections: sections,
+		Profile:  ProfileStaff,
+	})
+}
 
-	// Add auction info page with background
-	s.addPageWithBackground(pdf, "auction-info-bg.png")
-	s.addAuctionInfoContent(pdf, event.Name, event.EID, event.Currency, artists, auctionLots)
+// GenerateEventPaperworkContext is GenerateEventPaperwork with cancellation, progress reporting
+// and a rendering profile. The context is checked between sections and between artist pages.
+func (s *PaperworkPDFService) GenerateEventPaperworkContext(ctx context.Context, event *models.Event, artists []models.EventArtist, auctionLots []models.AuctionLot, opts RenderOptions) ([]byte, error) {
+	sections := opts.Sections
+	if len(sections) == 0 {
+		sections = DefaultSections
+	}
+	profile := opts.Profile
+	if profile == "" {
+		profile = ProfilePublic
+	}
+	progress := opts.Progress
+	if progress == nil {
+		progress = func(Section, bool) {}
+	}
 
-	// Add bio summary pages
-	s.addBioSummaryPages(pdf, event.Name, artists)
+	// Redact once, up front, so no section ever sees data the profile doesn't allow
+	artists = redactArtists(artists, profile)
+	auctionLots = redactLots(auctionLots, profile)
 
-	// Add individual artist pages with background (only for ready artists)
-	for _, artist := range artists {
-		// Skip confirmed-only artists for individual pages
-		if artist.Status == "confirmed-only" {
-			continue
+	// Pick and clean each bio once so every section prints the same text
+	artists = s.bioPolicy.applyBios(artists)
+
+	pdf := s.newDocument()
+	var debug *debugOverlay
+	if opts.Debug {
+		debug = newDebugOverlay(pdf)
+	}
+
+	for _, section := range sections {
+		if err := ctx.Err(); err != nil {
+			return nil, err
 		}
-		s.addPageWithBackground(pdf, "artist-page-bg.png")
-		s.addArtistPageContent(pdf, event.Name, event.EID, artist)
+		progress(section, false)
+		debug.startSection(s.layout.sectionGuides(section, profile.ShowsBidders())...)
+
+		switch section {
+		case SectionArtistList:
+			// Add artist list page with background
+			s.addPageWithBackground(pdf, "artist-list-bg.png")
+			s.addArtistListContent(pdf, event, artists)
+
+		case SectionAuction:
+			// Add auction info page with background
+			s.addPageWithBackground(pdf, "auction-info-bg.png")
+			s.addAuctionInfoContent(pdf, event, artists, auctionLots, profile.ShowsBidders())
+
+		case SectionBios:
+			// Add bio summary pages
+			s.addBioSummaryPages(pdf, event, artists, opts.BioBudget)
+
+		case SectionArtistPages:
+			// Add individual artist pages with background (only for ready artists)
+			for _, artist := range artists {
+				// Skip confirmed-only artists for individual pages
+				if artist.Status == "confirmed-only" {
+					continue
+				}
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+				s.addPageWithBackground(pdf, "artist-page-bg.png")
+				s.addArtistPageContent(pdf, event, artist)
+			}
+
+		case SectionBidHistory:
+			// Add bid history pages, one block per lot
+			s.addBidHistoryPages(pdf, event, artists, auctionLots)
+
+		default:
+			return nil, &RenderError{Section: section, Err: errors.New("unknown section")}
+		}
+
+		// gofpdf records the first drawing error and ignores everything after it
+		if err := pdf.Error(); err != nil {
+			return nil, &RenderError{Section: section, Err: err}
+		}
+		progress(section, true)
+	}
+
+	// A selection can legitimately produce no pages (e.g. bios only with no artists)
+	if pdf.PageNo() == 0 {
+		pdf.AddPage()
 	}
 
-	// Generate PDF
+	return s.outputDocument(pdf, "")
+}
+
+// GenerateArtistPage generates a single artist page, used for night-of reprints
+func (s *PaperworkPDFService) GenerateArtistPage(event *models.Event, artist models.EventArtist, profile Profile) ([]byte, error) {
+	if profile == "" {
+		profile = ProfilePublic
+	}
+	artist = s.bioPolicy.applyBios(redactArtists([]models.EventArtist{artist}, profile))[0]
+
+	pdf := s.newDocument()
+
+	s.addPageWithBackground(pdf, "artist-page-bg.png")
+	s.addArtistPageContent(pdf, event, artist)
+
+	return s.outputDocument(pdf, SectionArtistPages)
+}
+
+// newDocument creates a landscape Letter PDF with the custom fonts registered
+func (s *PaperworkPDFService) newDocument() *gofpdf.Fpdf {
+	// Create PDF in landscape mode
+	pdf := gofpdf.New("L", "mm", "Letter", "")
+	pdf.SetAutoPageBreak(false, 0)
+
+	// Add custom fonts
+	s.addCustomFonts(pdf)
+
+	return pdf
+}
+
+// outputDocument renders the finished PDF to bytes. Failures are reported as a RenderError
+// in section.
+func (s *PaperworkPDFService) outputDocument(pdf *gofpdf.Fpdf, section Section) ([]byte, error) {
 	var buf bytes.Buffer
 	if err := pdf.Output(&buf); err != nil {
-		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+		return nil, &RenderError{Section: section, Err: err}
 	}
 
 	return buf.Bytes(), nil
@@ -72,14 +203,15 @@ func (s *PaperworkPDFService) GenerateEventPaperwork(event *models.Event, artist
 
 // addCustomFonts registers the custom TTF fonts
 func (s *PaperworkPDFService) addCustomFonts(pdf *gofpdf.Fpdf) {
-	// Add Acumin Pro fonts
-	fonts := map[string]string{
-		"AcuminMedium":   "Acumin Pro SemiCond Medium.ttf",
-		"AcuminBold":     "Acumin Pro Cond Bold.ttf",
-		"AcuminSemibold": "Acumin Pro SemiCond Semibold.ttf",
+	// Add Acumin Pro fonts, in a fixed order so the same input renders the same bytes
+	names := make([]string, 0, len(customFonts))
+	for fontName := range customFonts {
+		names = append(names, fontName)
 	}
+	sort.Strings(names)
 
-	for fontName, fileName := range fonts {
+	for _, fontName := range names {
+		fileName := customFonts[fontName]
 		fontPath := filepath.Join(s.fontsPath, fileName)
 		if _, err := os.Stat(fontPath); err == nil {
 			pdf.AddUTF8Font(fontName, "", fontPath)
@@ -106,7 +238,7 @@ func (s *PaperworkPDFService) addPageWithBackground(pdf *gofpdf.Fpdf, background
 		pdf.RegisterImageOptions(bgPath, gofpdf.ImageOptions{ImageType: "PNG", ReadDpi: true})
 
 		// Place background image covering full page
-		pdf.ImageOptions(bgPath, 0, 0, 279.4, 215.9, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+		pdf.ImageOptions(bgPath, 0, 0, pageWidth, pageHeight, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
 
 		s.logger.Debug("Added background image", zap.String("background", backgroundFile))
 	} else {
@@ -116,362 +248,423 @@ func (s *PaperworkPDFService) addPageWithBackground(pdf *gofpdf.Fpdf, background
 	}
 }
 
-// addArtistListContent adds the artist list content
-func (s *PaperworkPDFService) addArtistListContent(pdf *gofpdf.Fpdf, eventName string, artists []models.EventArtist) {
+// addArtistListContent adds the artist list content, continuing on new pages as needed
+func (s *PaperworkPDFService) addArtistListContent(pdf *gofpdf.Fpdf, event *models.Event, artists []models.EventArtist) {
+	fields := s.layout.ArtistListPage.Fields
+
 	// Add content on top of background
-	pdf.SetFont("AcuminBold", "", 24)
-	pdf.SetXY(20, 20)
-	pdf.Cell(0, 10, cleanString(eventName))
+	title := s.layoutText("artistListPage.fields.title", fields.Title, event, TitleData{Event: event}, cleanString(event.Name))
+	fields.Title.cell(pdf, title)
 
 	// Artist table starting at specific position
-	pdf.SetFont("AcuminMedium", "", 10)
-	pdf.SetXY(20, 40)
+	pdf.SetXY(fields.Table.X, fields.Table.Y)
+
+	table := &pdfTable{
+		pdf:            pdf,
+		left:           fields.Table.X,
+		headers:        fields.Table.headers(),
+		colWidths:      fields.Table.widths(),
+		rowHeight:      fields.Table.RowHeight,
+		fontSize:       fields.Table.FontSize,
+		headerHeight:   fields.Table.HeaderHeight,
+		headerFontSize: fields.Table.HeaderFontSize,
+		continuePage: func() {
+			s.addPageWithBackground(pdf, "artist-list-bg.png")
+			continuedTitle(pdf, title, fields.Title)
+			pdf.SetXY(fields.Table.X, fields.Table.Y)
+		},
+	}
+	table.header()
 
-	// Table headers
-	headers := []string{"Round-Easel", "Artist Name"}
-	colWidths := []float64{40, 130}
+	// Table rows with full grid - only show ready artists
+	aligns := fields.Table.aligns()
+	for _, row := range BuildRosterRows(artists) {
+		table.row([]string{row.RoundEasel, cleanString(row.ArtistName)}, aligns, false)
+	}
+}
 
-	pdf.SetFont("AcuminSemibold", "", 10)
-	pdf.SetTextColor(0, 0, 0) // Ensure black text
-	pdf.SetDrawColor(200, 200, 200) // Light gray for grid
+// addAuctionInfoContent adds the auction information content, continuing on new pages as needed.
+// Without showBidders the bidder and payment columns are left out entirely.
+func (s *PaperworkPDFService) addAuctionInfoContent(pdf *gofpdf.Fpdf, event *models.Event, artists []models.EventArtist, auctionLots []models.AuctionLot, showBidders bool) {
+	fields := s.layout.AuctionPage.Fields
+	title := s.layoutText("auctionPage.fields.title", fields.Title, event, TitleData{Event: event}, "Auction & Bidding Information")
 
-	// Draw header row with full borders
-	x := pdf.GetX()
-	for i, header := range headers {
-		pdf.SetX(x) // Reset X position to ensure alignment
-		pdf.CellFormat(colWidths[i], 8, header, "1", 0, "C", false, 0, "") // Full border
-		x += colWidths[i]
+	// Add content on top of background - match original exactly
+	fields.Title.cell(pdf, title)
+
+	// Without bidders the narrower public table spreads its four columns over the page
+	layout := fields.Table
+	if !showBidders {
+		layout = fields.PublicTable
 	}
-	pdf.Ln(8)
 
-	// Table rows with full grid - only show ready artists
-	pdf.SetFont("AcuminMedium", "", 10)
-	for _, artist := range artists {
-		// Skip confirmed-only artists
-		if artist.Status == "confirmed-only" {
-			continue
-		}
+	// Auction table starting at specific position
+	pdf.SetXY(layout.X, layout.Y)
+
+	table := &pdfTable{
+		pdf:            pdf,
+		left:           layout.X,
+		headers:        layout.headers(),
+		colWidths:      layout.widths(),
+		rowHeight:      layout.RowHeight,
+		fontSize:       layout.FontSize,
+		headerHeight:   layout.HeaderHeight,
+		headerFontSize: layout.HeaderFontSize,
+		continuePage: func() {
+			s.addPageWithBackground(pdf, "auction-info-bg.png")
+			continuedTitle(pdf, title, fields.Title)
+			pdf.SetXY(layout.X, layout.Y)
+		},
+	}
+	table.header()
+	aligns := layout.aligns()
 
-		x = 20 // Reset to starting position
-		pdf.SetX(x)
+	// Table rows
+	for _, row := range BuildAuctionRows(event.EID, artists, auctionLots) {
+		bidCount := fmt.Sprintf("%d", row.BidCount)
+		topBid := "-"
+		bidderInfo := "-"
+		paymentStatus := "-"
 
-		roundEaselText := fmt.Sprintf("%d-%d", artist.RoundNumber, artist.EaselNumber)
-		artistName := artist.DisplayName
-		if artistName == "" {
-			artistName = artist.ArtistName
+		if row.TopBid > 0 {
+			topBid = fmt.Sprintf("%s%.0f", currencySymbol(event.Currency), row.TopBid)
 		}
-		if artistName == "" {
-			artistName = fmt.Sprintf("%s %s", artist.FirstName, artist.LastName)
+		if row.Bidder != "" || row.PaymentStatus != "" {
+			bidderInfo = row.Bidder
+			paymentStatus = row.PaymentStatus
 		}
 
-		// Draw cells with borders
-		pdf.CellFormat(colWidths[0], 8, roundEaselText, "1", 0, "C", false, 0, "")
-		pdf.CellFormat(colWidths[1], 8, cleanString(artistName), "1", 0, "L", false, 0, "")
-		pdf.Ln(8)
+		cells := []string{row.ID, cleanString(row.ArtistName), bidCount, topBid}
+		if showBidders {
+			cells = append(cells, cleanString(bidderInfo), cleanString(paymentStatus))
+		}
+		table.row(cells, aligns, false)
 	}
 }
 
-// addAuctionInfoContent adds the auction information content
-func (s *PaperworkPDFService) addAuctionInfoContent(pdf *gofpdf.Fpdf, eventName string, eventEID string, currency string, artists []models.EventArtist, auctionLots []models.AuctionLot) {
-	// Add content on top of background - match original exactly
-	pdf.SetFont("AcuminBold", "", 20)
-	pdf.SetXY(20, 20)
-	pdf.Cell(0, 10, "Auction & Bidding Information")
-
-	// Auction table starting at specific position
-	pdf.SetFont("AcuminMedium", "", 9)
-	pdf.SetXY(20, 40)
-
-	// Table headers
-	headers := []string{"EID-Round-Easel", "Artist Name", "# Bids", "Top Bid", "Bidder Info", "Payment Status"}
-	colWidths := []float64{40, 60, 20, 25, 60, 35}
+// addBidHistoryPages lists every bid of every lot, flowing onto as many pages as needed.
+// A lot that breaks across pages repeats its heading and column headers.
+func (s *PaperworkPDFService) addBidHistoryPages(pdf *gofpdf.Fpdf, event *models.Event, artists []models.EventArtist, auctionLots []models.AuctionLot) {
+	const (
+		title         = "Bid History"
+		headingHeight = 8
+		lotGap        = 6
+	)
 
-	pdf.SetFont("AcuminSemibold", "", 9)
-	pdf.SetTextColor(0, 0, 0)
-	pdf.SetDrawColor(200, 200, 200)
+	symbol := currencySymbol(event.Currency)
+	loc := eventLocation(event)
 
-	// Draw header row
-	x := pdf.GetX()
-	for i, header := range headers {
-		pdf.SetX(x)
-		pdf.CellFormat(colWidths[i], 8, header, "1", 0, "C", false, 0, "")
-		x += colWidths[i]
+	var current BidHistoryLot
+	lotHeading := func(continued bool) {
+		heading := fmt.Sprintf("%s  %s  (%d bids)", current.ID, cleanString(current.ArtistName), len(current.Bids))
+		if continued {
+			heading += " - continued"
+		}
+		pdf.SetFont("AcuminSemibold", "", 11)
+		pdf.SetX(tableLeft)
+		pdf.Cell(0, headingHeight, heading)
+		pdf.Ln(headingHeight)
 	}
-	pdf.Ln(8)
 
-	// Create a map of auction lots by round and easel for quick lookup
-	lotMap := make(map[string]models.AuctionLot)
-	for _, lot := range auctionLots {
-		key := fmt.Sprintf("%d-%d", lot.Round, lot.EaselNumber)
-		lotMap[key] = lot
+	table := &pdfTable{
+		pdf:       pdf,
+		left:      tableLeft,
+		headers:   []string{"#", "Bid Time", "Bidder", "Amount", "Increment", ""},
+		colWidths: []float64{12, 50, 80, 35, 35, 28},
+		rowHeight: 7,
+		fontSize:  9,
+		continuePage: func() {
+			s.addPageWithBackground(pdf, "auction-info-bg.png")
+			continuedTitle(pdf, title, titleField(20))
+			lotHeading(true)
+		},
 	}
+	aligns := []string{"C", "C", "L", "R", "R", "C"}
 
-	// Table rows
-	pdf.SetFont("AcuminMedium", "", 9)
-	for _, artist := range artists {
-		if artist.Status == "confirmed-only" {
-			continue
-		}
+	s.addPageWithBackground(pdf, "auction-info-bg.png")
+	titleField(20).cell(pdf, title)
+	pdf.SetXY(tableLeft, tableTop)
+
+	history := BuildBidHistory(event.EID, artists, auctionLots)
+	if len(history) == 0 {
+		pdf.SetFont("AcuminMedium", "", 12)
+		pdf.SetX(tableLeft)
+		pdf.Cell(0, 8, "No bids recorded for this event")
+		return
+	}
 
-		x = 20
-		pdf.SetX(x)
+	pdf.SetFillColor(255, 236, 179) // Soft amber highlight for winning bids
+	for i, lot := range history {
+		current = lot
 
-		eidRoundEasel := fmt.Sprintf("%s-%d-%d", eventEID, artist.RoundNumber, artist.EaselNumber)
-		artistName := artist.DisplayName
-		if artistName == "" {
-			artistName = artist.ArtistName
-		}
-		if artistName == "" {
-			artistName = fmt.Sprintf("%s %s", artist.FirstName, artist.LastName)
+		// Keep the heading, column headers and first bid together
+		if i > 0 && !table.fits(headingHeight+2*table.rowHeight) {
+			s.addPageWithBackground(pdf, "auction-info-bg.png")
+			continuedTitle(pdf, title, titleField(20))
 		}
+		lotHeading(false)
+		table.header()
 
-		// Look up auction data
-		lotKey := fmt.Sprintf("%d-%d", artist.RoundNumber, artist.EaselNumber)
-		lot, hasLot := lotMap[lotKey]
-
-		bidCount := "0"
-		topBid := "-"
-		bidderInfo := "-"
-		paymentStatus := "-"
-
-		if hasLot {
-			bidCount = fmt.Sprintf("%d", lot.BidCount)
-			if lot.HighestBid > 0 {
-				currencySymbol := "$"
-				if currency == "EUR" {
-					currencySymbol = "€"
-				} else if currency == "GBP" {
-					currencySymbol = "£"
-				}
-				topBid = fmt.Sprintf("%s%.0f", currencySymbol, lot.HighestBid)
+		for n, bid := range lot.Bids {
+			bidTime := "-"
+			if !bid.Time.IsZero() {
+				bidTime = bid.Time.In(loc).Format("Jan 2 3:04:05 PM")
 			}
-			if lot.WinningBid != nil {
-				bidderInfo = lot.WinningBid.BidderName
-				if bidderInfo == "" {
-					bidderInfo = lot.WinningBid.BidderEmail
-				}
-				paymentStatus = lot.WinningBid.PaymentStatus
+			increment := "-"
+			if n > 0 {
+				increment = "+" + formatAmount(symbol, bid.Increment)
+			}
+			winning := ""
+			if bid.Winning {
+				winning = "Winning"
 			}
+
+			table.row([]string{
+				fmt.Sprintf("%d", n+1),
+				bidTime,
+				cleanString(bid.Bidder),
+				formatAmount(symbol, bid.Amount),
+				increment,
+				winning,
+			}, aligns, bid.Winning)
 		}
 
-		// Draw cells
-		pdf.CellFormat(colWidths[0], 8, eidRoundEasel, "1", 0, "C", false, 0, "")
-		pdf.CellFormat(colWidths[1], 8, cleanString(artistName), "1", 0, "L", false, 0, "")
-		pdf.CellFormat(colWidths[2], 8, bidCount, "1", 0, "C", false, 0, "")
-		pdf.CellFormat(colWidths[3], 8, topBid, "1", 0, "C", false, 0, "")
-		pdf.CellFormat(colWidths[4], 8, cleanString(bidderInfo), "1", 0, "L", false, 0, "")
-		pdf.CellFormat(colWidths[5], 8, cleanString(paymentStatus), "1", 0, "C", false, 0, "")
-		pdf.Ln(8)
+		pdf.Ln(lotGap)
 	}
 }
 
-// addBioSummaryPages adds bio summary pages grouped by round
-func (s *PaperworkPDFService) addBioSummaryPages(pdf *gofpdf.Fpdf, eventName string, artists []models.EventArtist) {
-	// Group artists by round
-	round1Artists := []models.EventArtist{}
-	round2Artists := []models.EventArtist{}
-	unmatchedArtists := []models.EventArtist{}
+// formatAmount prints whole amounts without cents, e.g. $150 or $152.50
+func formatAmount(symbol string, amount float64) string {
+	if amount == float64(int64(amount)) {
+		return fmt.Sprintf("%s%.0f", symbol, amount)
+	}
+	return fmt.Sprintf("%s%.2f", symbol, amount)
+}
 
-	for _, artist := range artists {
-		if artist.Status == "confirmed-only" {
-			unmatchedArtists = append(unmatchedArtists, artist)
-		} else if artist.RoundNumber == 1 {
-			round1Artists = append(round1Artists, artist)
-		} else if artist.RoundNumber == 2 {
-			round2Artists = append(round2Artists, artist)
+// addBioSummaryPages adds bio summary pages, one group per round in order, then artists
+// without a round, then confirmed-only artists
+func (s *PaperworkPDFService) addBioSummaryPages(pdf *gofpdf.Fpdf, event *models.Event, artists []models.EventArtist, budget BioBudget) {
+	for _, group := range groupBios(artists, event.RoundLabels) {
+		if group.RoundNumber == 0 && !group.ConfirmedOnly {
+			s.logger.Warn("Artists without a round listed separately in bio summaries",
+				zap.String("eid", event.EID),
+				zap.Int("artists", len(group.Artists)))
 		}
-	}
 
-	// Add Round 1 bios if we have artists
-	if len(round1Artists) > 0 {
 		s.addPageWithBackground(pdf, "artist-list-bg.png")
-		s.addRoundBiosContent(pdf, eventName, "Round 1 Artist Bios", round1Artists)
+		s.addRoundBiosContent(pdf, event.Name, group.Title, group.Artists, budget)
 	}
+}
 
-	// Add Round 2 bios if we have artists
-	if len(round2Artists) > 0 {
-		s.addPageWithBackground(pdf, "artist-list-bg.png")
-		s.addRoundBiosContent(pdf, eventName, "Round 2 Artist Bios", round2Artists)
-	}
+// addRoundBiosContent adds bio content for a specific round, flowing onto continuation pages.
+// An artist's name is never left at the bottom of a page without the first lines of their bio.
+func (s *PaperworkPDFService) addRoundBiosContent(pdf *gofpdf.Fpdf, eventName string, roundTitle string, artists []models.EventArtist, budget BioBudget) {
+	const (
+		nameHeight = 8
+		lineHeight = 6
+		keepLines  = 3 // Bio lines kept on the same page as the artist name
+		bioGap     = 4
+	)
 
-	// Add unmatched/confirmed-only artists if we have them
-	if len(unmatchedArtists) > 0 {
-		s.addPageWithBackground(pdf, "artist-list-bg.png")
-		s.addRoundBiosContent(pdf, eventName, "Additional Artist Bios", unmatchedArtists)
-	}
-}
+	title := fmt.Sprintf("%s - %s", cleanString(eventName), roundTitle)
+	titleField(24).cell(pdf, title)
 
-// addRoundBiosContent adds bio content for a specific round
-func (s *PaperworkPDFService) addRoundBiosContent(pdf *gofpdf.Fpdf, eventName string, roundTitle string, artists []models.EventArtist) {
-	pdf.SetFont("AcuminBold", "", 24)
-	pdf.SetXY(20, 20)
-	pdf.Cell(0, 10, fmt.Sprintf("%s - %s", cleanString(eventName), roundTitle))
+	pdf.SetXY(tableLeft, tableTop)
 
-	pdf.SetFont("AcuminMedium", "", 10)
-	pdf.SetXY(20, 40)
+	// Same width MultiCell(0, ...) would use from the left margin
+	pageWidth, _ := pdf.GetPageSize()
+	_, _, rightMargin, _ := pdf.GetMargins()
+	width := pageWidth - rightMargin - tableLeft
 
 	for _, artist := range artists {
-		artistName := artist.DisplayName
-		if artistName == "" {
-			artistName = artist.ArtistName
+		// Wrap the bio first so we know how much of it has to stay with the name
+		bio := artist.Bio
+		if bio == "" {
+			bio = "No bio available"
+		}
+		layout := bioLayout(pdf, bio, "AcuminMedium", 10, width, lineHeight, budget)
+
+		keepHeight := 0.0
+		for i, line := range layout.Lines {
+			if i == keepLines {
+				break
+			}
+			keepHeight += line.Gap + lineHeight
 		}
-		if artistName == "" {
-			artistName = fmt.Sprintf("%s %s", artist.FirstName, artist.LastName)
+		if pdf.GetY()+nameHeight+keepHeight > tableBottom {
+			s.addPageWithBackground(pdf, "artist-list-bg.png")
+			continuedTitle(pdf, title, titleField(24))
 		}
 
 		// Add artist name
 		pdf.SetFont("AcuminSemibold", "", 12)
-		pdf.Cell(0, 8, cleanString(artistName))
-		pdf.Ln(8)
-
-		// Add bio if available
-		if artist.Bio != "" {
-			pdf.SetFont("AcuminMedium", "", 10)
-			bio := cleanString(artist.Bio)
-			// Word wrap bio text
-			pdf.MultiCell(0, 6, bio, "", "L", false)
-			pdf.Ln(4)
-		} else {
-			pdf.SetFont("AcuminMedium", "", 10)
-			pdf.Cell(0, 6, "No bio available")
-			pdf.Ln(10)
+		pdf.SetX(tableLeft)
+		pdf.Cell(0, nameHeight, cleanString(resolveArtistName(artist)))
+		pdf.Ln(nameHeight)
+
+		// Add the bio a line at a time so it can continue on the next page
+		for _, line := range layout.Lines {
+			y := pdf.GetY() + line.Gap
+			if y+lineHeight > tableBottom {
+				s.addPageWithBackground(pdf, "artist-list-bg.png")
+				continuedTitle(pdf, title, titleField(24))
+				y = pdf.GetY()
+			}
+			drawRichLine(pdf, layout, line, tableLeft, y)
+			pdf.SetXY(tableLeft, y+lineHeight)
 		}
+		pdf.Ln(bioGap)
 	}
 }
 
-// addArtistPageContent adds individual artist page content
-func (s *PaperworkPDFService) addArtistPageContent(pdf *gofpdf.Fpdf, eventName string, eventEID string, artist models.EventArtist) {
-	// Constants for layout - swapped top and bottom sections
-	const (
-		pageWidth     = 279.4
-		pageHeight    = 215.9
-		midPoint      = 107.95  // Vertical midpoint of the page
-
-		// BOTTOM SECTION (was top) - QR and name info
-		// Same distance from midpoint as they were from top
-		qrX           = 20                // QR code on left side
-		qrY           = midPoint + 45     // 152.95 (was 45 from top)
-		qrSize        = 42                // QR code size
-		nameStartX    = 70                // Artist name starts at X=70
-		nameStartY    = midPoint + 48     // 155.95 (was 48 from top)
-		eventY        = midPoint + 69     // 176.95 (was 69 from top)
-		roundY        = midPoint + 77     // 184.95 (was 77 from top)
-
-		// TOP SECTION (was bottom) - Bio and event history
-		// Same distance from top as it was from midpoint (118 - 107.95 = 10.05)
-		topSectionY   = 10.05             // Start position for bio/history section
-	)
+// bioLayout parses and wraps a bio for the summary pages, applying the budget
+func bioLayout(pdf *gofpdf.Fpdf, bio string, font string, fontSize float64, width float64, lineHeight float64, budget BioBudget) richLayout {
+	// Leave the cell margin on both sides, as MultiCell does
+	width -= 2 * pdf.GetCellMargin()
 
-	artistName := artist.DisplayName
-	if artistName == "" {
-		artistName = artist.ArtistName
+	words := parseMarkdown(bio)
+	if budget.MaxChars > 0 {
+		words, _ = truncateWords(words, budget.MaxChars)
 	}
-	if artistName == "" {
-		artistName = fmt.Sprintf("%s %s", artist.FirstName, artist.LastName)
+
+	layout := layoutRich(pdf, font, words, width, fontSize, lineHeight)
+	if budget.MaxLines > 0 && len(layout.Lines) > budget.MaxLines {
+		// Drop words from the end until the text and its ellipsis fit the line budget
+		for n := layout.wordCount(budget.MaxLines); n > 0; n-- {
+			candidate := layoutRich(pdf, font, withEllipsis(words[:n], false), width, fontSize, lineHeight)
+			if len(candidate.Lines) <= budget.MaxLines || n == 1 {
+				return candidate
+			}
+		}
 	}
+	return layout
+}
+
+// addArtistPageContent adds individual artist page content
+func (s *PaperworkPDFService) addArtistPageContent(pdf *gofpdf.Fpdf, event *models.Event, artist models.EventArtist) {
+	fields := s.layout.ArtistPage.Fields
+	data := ArtistPageData{DocArtist: docArtists(event.EID, []models.EventArtist{artist})[0], Event: event}
 
-	// TOP SECTION (was bottom): Bio and Event History - now at the top
-	// Two-column layout for what was the bottom half
-	columnWidth := float64(115) // Width of each column
-	columnGap := float64(10) // Gap between columns
-	leftColumnX := float64(20)
-	rightColumnX := leftColumnX + columnWidth + columnGap
-	rightColumnMaxWidth := float64(279.4 - rightColumnX - 20)
+	artistName := s.layoutText("artistPage.fields.artistName", fields.ArtistName, event, data, resolveArtistName(artist))
 
+	// TOP SECTION: Event history on the left, bio on the right
 	// LEFT COLUMN: Event history
 	// Display condensed event history
-	pdf.SetFont("AcuminMedium", "", 12) // Increased font size by 4pt (was 8pt)
-
-	lineHeight := float64(6) // Increased line height for larger font
-	maxEvents := 20 // Maximum events to display
+	history := fields.EventHistory
+	history.setText(pdf, history.FontSize)
 
 	for i, event := range artist.EventHistory {
-		if i >= maxEvents {
-			pdf.SetXY(leftColumnX, topSectionY + float64(i)*lineHeight)
-			pdf.Cell(columnWidth, lineHeight, fmt.Sprintf("... and %d more events", len(artist.EventHistory)-maxEvents))
+		pdf.SetXY(history.listItem(i))
+		if i >= history.MaxItems {
+			pdf.Cell(history.Width, history.LineHeight, fmt.Sprintf("... and %d more events", len(artist.EventHistory)-history.MaxItems))
 			break
 		}
 
-		pdf.SetXY(leftColumnX, topSectionY + float64(i)*lineHeight)
-		// Display event details in a condensed format with winner status
-		winnerText := ""
-		if event.IsWinner {
-			winnerText = " W" // Add W for winners
-		}
-		pdf.Cell(columnWidth, lineHeight, fmt.Sprintf("%s R%d-E%d%s", event.EventEID, event.Round, event.EaselNumber, winnerText))
+		pdf.Cell(history.Width, history.LineHeight, historySummary(event))
 	}
 
-	// RIGHT COLUMN: Artist Bio
-	pdf.SetFont("AcuminMedium", "", 14) // Increased font size by 4pt (was 10pt)
-	pdf.SetXY(rightColumnX, topSectionY)
-
-	if artist.Bio != "" {
-		// Use MultiCell for automatic word wrapping
-		pdf.MultiCell(rightColumnMaxWidth, 6, cleanString(artist.Bio), "", "L", false)
-	} else {
-		pdf.Cell(rightColumnMaxWidth, 6, "No bio available")
+	// RIGHT COLUMN: Artist Bio, fitted into its box above the QR code and name block
+	bio := fields.Bio
+	bioBox := textBox{
+		X:           bio.X,
+		Y:           bio.Y,
+		Width:       bio.Width,
+		Height:      bio.Height,
+		Font:        bio.Font,
+		MaxFontSize: bio.FontSize,
+		MinFontSize: bio.MinFontSize,
+		FontStep:    0.5,
+		LineSpacing: bio.LineHeight / (bio.FontSize * ptToMM), // lineHeight is given at the full font size
+	}
+	if bioBox.MinFontSize == 0 {
+		bioBox.MinFontSize = bioBox.MaxFontSize
 	}
 
-	// BOTTOM SECTION (was top): QR Code, Name, and Event Info - now at the bottom
-	// Generate QR code - prioritize Instagram, fallback to event page
-	var qrURL string
-	if artist.Instagram != "" {
-		// Use Instagram URL if available
-		if strings.HasPrefix(artist.Instagram, "http") {
-			qrURL = artist.Instagram
-		} else {
-			qrURL = fmt.Sprintf("https://instagram.com/%s", strings.TrimPrefix(artist.Instagram, "@"))
+	pdf.SetTextColor(bio.Color.R, bio.Color.G, bio.Color.B)
+	if artist.Bio != "" {
+		fit := fitText(pdf, parseMarkdown(artist.Bio), bioBox)
+		bioBox.draw(pdf, fit)
+		if fit.Truncated {
+			s.logger.Warn("Artist bio truncated to fit the artist page",
+				zap.String("eid", event.EID),
+				zap.Int("entry_id", artist.EntryID),
+				zap.String("artist", artistName),
+				zap.Int("round", artist.RoundNumber),
+				zap.Int("easel", artist.EaselNumber),
+				zap.Int("bio_chars", len([]rune(artist.Bio))))
 		}
 	} else {
-		// Fallback to event page
-		qrURL = fmt.Sprintf("https://artb.art/event/%s", eventEID)
+		bio.setText(pdf, bio.FontSize)
+		pdf.SetXY(bio.X, bio.Y)
+		pdf.Cell(bio.Width, bio.LineHeight, "No bio available")
 	}
 
-	qr, err := qrcode.New(qrURL, qrcode.Medium)
-	if err == nil {
-		qr.DisableBorder = true
-		img := qr.Image(256)
-
-		// Convert to PNG using original method
-		var buf bytes.Buffer
-		if err := png.Encode(&buf, img); err == nil {
-			imgReader := bytes.NewReader(buf.Bytes())
-			imgName := fmt.Sprintf("qr_%d", artist.EntryID)
-			pdf.RegisterImageOptionsReader(imgName, gofpdf.ImageOptions{ImageType: "png"}, imgReader)
-
-			// Place QR code at new bottom position
-			pdf.ImageOptions(imgName, qrX, qrY, qrSize, qrSize, false, gofpdf.ImageOptions{ImageType: "png"}, 0, "")
-		}
-	}
+	// BOTTOM SECTION: QR Code, Name, and Event Info
+	drawQRCode(pdf, data.QRURL, fmt.Sprintf("qr_%d", artist.EntryID), fields.QRCode)
 
-	// Artist name with dynamic font sizing - now in bottom section
-	rightMargin := float64(20)
-	availableWidth := pageWidth - nameStartX - rightMargin
+	// Artist name with dynamic font sizing
+	name := fields.ArtistName
 
-	// Start with default font size and check if name fits
-	fontSize := float64(49) // Default size
-	pdf.SetFont("AcuminBold", "", fontSize)
+	// Start with the layout's font size and shrink in 2pt steps until the name fits
+	fontSize := name.FontSize
+	pdf.SetFont(name.Font, "", fontSize)
 	nameWidth := pdf.GetStringWidth(artistName)
 
 	// Reduce font size if name is too long
-	for nameWidth > availableWidth && fontSize > 20 {
+	for nameWidth > name.Width && fontSize > name.MinFontSize && fontSize > 2 {
 		fontSize -= 2
-		pdf.SetFont("AcuminBold", "", fontSize)
+		pdf.SetFont(name.Font, "", fontSize)
 		nameWidth = pdf.GetStringWidth(artistName)
 	}
 
-	pdf.SetTextColor(0, 0, 0) // Black text
-	pdf.SetXY(nameStartX, nameStartY)
-	pdf.Cell(availableWidth, 10, cleanString(artistName))
+	name.setText(pdf, fontSize)
+	pdf.SetXY(name.X, name.Y)
+	pdf.Cell(name.Width, name.Height, cleanString(artistName))
 
-	// Event name above round/easel - now in bottom section
-	pdf.SetFont("AcuminMedium", "", 18)
-	pdf.SetXY(nameStartX, eventY)
-	pdf.Cell(0, 6, cleanString(eventName))
+	// Event name above round/easel
+	fields.EventName.cell(pdf, s.layoutText("artistPage.fields.eventName", fields.EventName, event, data, cleanString(event.Name)))
 
-	pdf.SetFont("AcuminMedium", "", 21)
-	pdf.SetXY(nameStartX, roundY)
-	pdf.Cell(0, 8, fmt.Sprintf("Round %d - Easel %d", artist.RoundNumber, artist.EaselNumber))
+	fields.RoundEasel.cell(pdf, s.layoutText("artistPage.fields.roundEasel", fields.RoundEasel, event, data, data.RoundEasel))
+}
+
+// historySummary condenses a past event to one line with winner status, e.g. "AB3001 R1-E4 W"
+func historySummary(event models.ArtistEvent) string {
+	winnerText := ""
+	if event.IsWinner {
+		winnerText = " W" // Add W for winners
+	}
+	return fmt.Sprintf("%s R%d-E%d%s", event.EventEID, event.Round, event.EaselNumber, winnerText)
+}
+
+// artistQRURL is the QR code link for an artist - prioritize Instagram, fallback to event page
+func artistQRURL(eventEID string, artist models.EventArtist) string {
+	if artist.Instagram != "" {
+		// Use Instagram URL if available
+		if strings.HasPrefix(artist.Instagram, "http") {
+			return artist.Instagram
+		}
+		return fmt.Sprintf("https://instagram.com/%s", strings.TrimPrefix(artist.Instagram, "@"))
+	}
+	// Fallback to event page
+	return fmt.Sprintf("https://artb.art/event/%s", eventEID)
+}
+
+// drawQRCode draws a QR code for the URL into the box; imgName must be unique per URL
+func drawQRCode(pdf *gofpdf.Fpdf, url string, imgName string, box LayoutField) {
+	qr, err := qrcode.New(url, qrcode.Medium)
+	if err != nil {
+		return
+	}
+	qr.DisableBorder = true
+	img := qr.Image(256)
+
+	// Convert to PNG using original method
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return
+	}
+	pdf.RegisterImageOptionsReader(imgName, gofpdf.ImageOptions{ImageType: "png"}, bytes.NewReader(buf.Bytes()))
+	pdf.ImageOptions(imgName, box.X, box.Y, box.Width, box.Height, false, gofpdf.ImageOptions{ImageType: "png"}, 0, "")
 }
 
 // cleanString removes problematic characters that can cause PDF issues
@@ -489,4 +682,4 @@ func cleanString(s string) string {
 	s = strings.ReplaceAll(s, "\x00", "")
 
 	return s
-}
\ No newline at end of file
+}
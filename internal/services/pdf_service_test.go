@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"paperwork-service/internal/models"
+
+	"go.uber.org/zap"
+)
+
+// pdfPagePattern matches each page object, but not the /Pages tree
+var pdfPagePattern = regexp.MustCompile(`/Type /Page[^s]`)
+
+func TestTablesContinueAcrossPages(t *testing.T) {
+	event := &models.Event{EID: "AB4000", Name: "Big Night", Currency: "CAD"}
+	var artists []models.EventArtist
+	var lots []models.AuctionLot
+	for i := 1; i <= 64; i++ {
+		round := 1 + (i-1)/20
+		artists = append(artists, models.EventArtist{RoundNumber: round, EaselNumber: i, ArtistName: fmt.Sprintf("Painter Number %02d", i)})
+		lots = append(lots, models.AuctionLot{Round: round, EaselNumber: i, BidCount: 1, HighestBid: 100,
+			WinningBid: &models.Bid{BidderName: "Wendy Winner", PaymentStatus: "paid"}})
+	}
+
+	s := NewPaperworkPDFService(zap.NewNop(), "../../templates", DefaultBioPolicy, DefaultLayout(), nil)
+	for _, profile := range []Profile{ProfileStaff, ProfilePublic} {
+		t.Run(string(profile), func(t *testing.T) {
+			pdf, err := s.GenerateEventPaperworkContext(context.Background(), event, artists, lots, RenderOptions{
+				Sections: []Section{SectionArtistList, SectionAuction},
+				Profile:  profile,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// One page each would hold only the first rows, and every page after the first of
+			// each table repeats its title as "(continued)"
+			pages := len(pdfPagePattern.FindAll(pdf, -1))
+			if pages < 4 {
+				t.Errorf("rendered %d pages, want both tables to continue onto more pages", pages)
+			}
+			content := pdfContent(t, pdf)
+			if n := pdfCount(content, "continued"); n != pages-2 {
+				t.Errorf("%d continued titles on %d pages, want %d", n, pages, pages-2)
+			}
+			// Every artist is in the roster and the auction table exactly once, whichever page
+			// their row fell on
+			for _, artist := range artists {
+				if n := pdfCount(content, artist.ArtistName); n != 2 {
+					t.Errorf("%s drawn %d times, want 2", artist.ArtistName, n)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	// tableLeft is the default left edge of the paperwork tables and page titles
+	tableLeft = 20
+	// tableTop is where a table starts below the page title
+	tableTop = 40
+	// tableBottom is the lowest Y a table row may end at, clear of the background's footer
+	tableBottom = 195
+)
+
+// pdfTable draws a bordered table that continues onto a new page when it runs out of room.
+// Auto page breaks are off for the whole document, so rows must never be drawn past tableBottom.
+type pdfTable struct {
+	pdf       *gofpdf.Fpdf
+	left      float64
+	headers   []string
+	colWidths []float64
+	rowHeight float64
+	fontSize  float64
+	// headerHeight and headerFontSize size the header row; zero uses rowHeight and fontSize
+	headerHeight   float64
+	headerFontSize float64
+	// continuePage adds a continuation page (background and title) and leaves the cursor
+	// where the repeated header row should go
+	continuePage func()
+}
+
+// header draws the header row at the current Y
+func (t *pdfTable) header() {
+	height, fontSize := t.rowHeight, t.fontSize
+	if t.headerHeight > 0 {
+		height = t.headerHeight
+	}
+	if t.headerFontSize > 0 {
+		fontSize = t.headerFontSize
+	}
+
+	t.pdf.SetFont("AcuminSemibold", "", fontSize)
+	t.pdf.SetTextColor(0, 0, 0)       // Ensure black text
+	t.pdf.SetDrawColor(200, 200, 200) // Light gray for grid
+
+	t.pdf.SetX(t.left)
+	for i, header := range t.headers {
+		t.pdf.CellFormat(t.colWidths[i], height, header, "1", 0, "C", false, 0, "")
+	}
+	t.pdf.Ln(height)
+}
+
+// fits reports whether the given height still fits on the current page
+func (t *pdfTable) fits(height float64) bool {
+	return t.pdf.GetY()+height <= tableBottom
+}
+
+// breakPage moves the table onto a continuation page and repeats the header row
+func (t *pdfTable) breakPage() {
+	t.continuePage()
+	t.header()
+}
+
+// row draws one row, breaking onto a new page first if it would not fit.
+// Highlighted rows use the semibold weight and the current fill color.
+func (t *pdfTable) row(cells []string, aligns []string, highlight bool) {
+	if !t.fits(t.rowHeight) {
+		t.breakPage()
+	}
+
+	if highlight {
+		t.pdf.SetFont("AcuminSemibold", "", t.fontSize)
+	} else {
+		t.pdf.SetFont("AcuminMedium", "", t.fontSize)
+	}
+
+	t.pdf.SetX(t.left)
+	for i, cell := range cells {
+		t.pdf.CellFormat(t.colWidths[i], t.rowHeight, cell, "1", 0, aligns[i], highlight, 0, "")
+	}
+	t.pdf.Ln(t.rowHeight)
+}
+
+// continuedTitle draws a page title with the "(continued)" marker used on overflow pages
+func continuedTitle(pdf *gofpdf.Fpdf, title string, field LayoutField) {
+	field.cell(pdf, title+" (continued)")
+	pdf.SetXY(tableLeft, tableTop)
+}
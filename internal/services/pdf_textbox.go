@@ -0,0 +1,248 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// ptToMM converts a font size in points to millimetres
+const ptToMM = 25.4 / 72
+
+// richFont maps an inline style to an Acumin weight. No italic face ships with the
+// templates, so italic is set in the semibold weight and bold in the bold weight.
+// Links are underlined.
+func richFont(base string, style richStyle) (string, string) {
+	family := base
+	switch {
+	case style.Bold:
+		family = "AcuminBold"
+	case style.Italic:
+		family = "AcuminSemibold"
+	}
+	if style.Link != "" {
+		return family, "U"
+	}
+	return family, ""
+}
+
+// placedWord is a word positioned on a line, X relative to the line start
+type placedWord struct {
+	Word richWord
+	X    float64
+}
+
+// richLine is one wrapped line of rich text
+type richLine struct {
+	Words []placedWord
+	Width float64
+	// Gap is extra space above the line, used between paragraphs
+	Gap float64
+}
+
+// richLayout is rich text wrapped to a width at one font size
+type richLayout struct {
+	Font       string
+	FontSize   float64
+	LineHeight float64
+	Lines      []richLine
+}
+
+// height is the total height of the wrapped text
+func (l richLayout) height() float64 {
+	h := 0.0
+	for _, line := range l.Lines {
+		h += line.Gap + l.LineHeight
+	}
+	return h
+}
+
+// wordCount is the number of words placed on the first n lines
+func (l richLayout) wordCount(n int) int {
+	count := 0
+	for i := 0; i < n && i < len(l.Lines); i++ {
+		count += len(l.Lines[i].Words)
+	}
+	return count
+}
+
+// layoutRich wraps words to the width, breaking at spaces, line breaks and paragraphs.
+// A word wider than the line is placed on a line of its own.
+func layoutRich(pdf *gofpdf.Fpdf, font string, words []richWord, width, size, lineHeight float64) richLayout {
+	layout := richLayout{Font: font, FontSize: size, LineHeight: lineHeight}
+
+	pdf.SetFont(font, "", size)
+	space := pdf.GetStringWidth(" ")
+
+	for _, word := range words {
+		wordWidth := 0.0
+		for _, part := range word.Parts {
+			family, style := richFont(font, part.Style)
+			pdf.SetFont(family, style, size)
+			wordWidth += pdf.GetStringWidth(part.Text)
+		}
+
+		n := len(layout.Lines)
+		if n == 0 || word.Break != breakNone || layout.Lines[n-1].Width+space+wordWidth > width {
+			line := richLine{}
+			if word.Break == breakParagraph && n > 0 {
+				line.Gap = lineHeight / 2
+			}
+			layout.Lines = append(layout.Lines, line)
+			n++
+		}
+
+		line := &layout.Lines[n-1]
+		x := 0.0
+		if len(line.Words) > 0 {
+			x = line.Width + space
+		}
+		line.Words = append(line.Words, placedWord{Word: word, X: x})
+		line.Width = x + wordWidth
+	}
+	return layout
+}
+
+// drawRichLine draws one line with its top-left corner at x, y. Links are clickable.
+func drawRichLine(pdf *gofpdf.Fpdf, layout richLayout, line richLine, x, y float64) {
+	for _, placed := range line.Words {
+		cx := x + placed.X
+		for _, part := range placed.Word.Parts {
+			family, style := richFont(layout.Font, part.Style)
+			pdf.SetFont(family, style, layout.FontSize)
+			w := pdf.GetStringWidth(part.Text)
+			pdf.SetXY(cx, y)
+			pdf.CellFormat(w, layout.LineHeight, part.Text, "", 0, "L", false, 0, linkURL(part.Style.Link))
+			cx += w
+		}
+	}
+	pdf.SetFont(layout.Font, "", layout.FontSize)
+}
+
+// linkURL makes a bio link absolute so PDF viewers open it
+func linkURL(link string) string {
+	if link == "" || strings.Contains(link, "://") || strings.HasPrefix(link, "mailto:") {
+		return link
+	}
+	return "https://" + link
+}
+
+// withEllipsis returns the words with an ellipsis after the last one. A separate ellipsis
+// follows a whole sentence; otherwise it is attached to the last word.
+func withEllipsis(words []richWord, separate bool) []richWord {
+	out := append([]richWord(nil), words...)
+	if separate || len(out) == 0 {
+		return append(out, richWord{Parts: []richFragment{{Text: "..."}}})
+	}
+
+	last := out[len(out)-1]
+	last.Parts = append([]richFragment(nil), last.Parts...)
+	tail := &last.Parts[len(last.Parts)-1]
+	tail.Text = strings.TrimRight(tail.Text, ".,;:") + "..."
+	out[len(out)-1] = last
+	return out
+}
+
+// truncateWords keeps whole words up to max characters, ending with an ellipsis
+func truncateWords(words []richWord, max int) ([]richWord, bool) {
+	total := 0
+	for i, word := range words {
+		length := len([]rune(word.text()))
+		if i > 0 {
+			length++ // The separating space or break
+		}
+		if total+length > max {
+			if i == 0 {
+				i = 1 // Always keep the first word
+			}
+			return withEllipsis(words[:i], false), true
+		}
+		total += length
+	}
+	return words, false
+}
+
+// textBox is a fixed rectangle that rich text is fitted into
+type textBox struct {
+	X, Y, Width, Height float64
+	Font                string
+	MaxFontSize         float64
+	MinFontSize         float64
+	FontStep            float64
+	// LineSpacing is the line height as a multiple of the font size in mm
+	LineSpacing float64
+}
+
+// textFit is the result of fitting text into a textBox
+type textFit struct {
+	Layout    richLayout
+	Truncated bool
+}
+
+// fitText shrinks the font step by step until the text fits the box. If it still overflows
+// at the smallest size, the text is cut at the last whole sentence that fits, with an ellipsis,
+// or failing that at a word. A box too short for one line gets a lone ellipsis.
+func fitText(pdf *gofpdf.Fpdf, words []richWord, box textBox) textFit {
+	for size := box.MaxFontSize; size >= box.MinFontSize; size -= box.FontStep {
+		layout := box.layout(pdf, words, size)
+		if layout.height() <= box.Height {
+			return textFit{Layout: layout}
+		}
+		if box.FontStep <= 0 {
+			break
+		}
+	}
+
+	// Still too long at the floor: keep as many whole sentences as fit
+	size := box.MinFontSize
+	best := richLayout{}
+	for i, word := range words[:len(words)-1] {
+		if !endsSentence(word.text()) {
+			continue
+		}
+		candidate := box.layout(pdf, withEllipsis(words[:i+1], true), size)
+		if candidate.height() > box.Height {
+			break
+		}
+		best = candidate
+	}
+	if best.Lines != nil {
+		return textFit{Layout: best, Truncated: true}
+	}
+
+	// Not even the first sentence fits; cut at a word instead
+	full := box.layout(pdf, words, size)
+	lines := int(box.Height / full.LineHeight)
+	for n := full.wordCount(lines); n > 0; n-- {
+		candidate := box.layout(pdf, withEllipsis(words[:n], false), size)
+		if candidate.height() <= box.Height || n == 1 {
+			return textFit{Layout: candidate, Truncated: true}
+		}
+	}
+
+	// The box is shorter than one line: print only an ellipsis rather than the whole text
+	return textFit{Layout: box.layout(pdf, withEllipsis(nil, true), size), Truncated: true}
+}
+
+// layout wraps words to the box width at the given font size
+func (b textBox) layout(pdf *gofpdf.Fpdf, words []richWord, size float64) richLayout {
+	// Leave the cell margin on both sides, as MultiCell does
+	width := b.Width - 2*pdf.GetCellMargin()
+	return layoutRich(pdf, b.Font, words, width, size, size*ptToMM*b.LineSpacing)
+}
+
+// draw writes fitted text into the box, top-left aligned
+func (b textBox) draw(pdf *gofpdf.Fpdf, fit textFit) {
+	y := b.Y
+	for _, line := range fit.Layout.Lines {
+		y += line.Gap
+		drawRichLine(pdf, fit.Layout, line, b.X, y)
+		y += fit.Layout.LineHeight
+	}
+}
+
+// endsSentence reports whether a word ends a sentence, allowing closing quotes and brackets
+func endsSentence(word string) bool {
+	word = strings.TrimRight(word, "\"')]")
+	return strings.HasSuffix(word, ".") || strings.HasSuffix(word, "!") || strings.HasSuffix(word, "?")
+}
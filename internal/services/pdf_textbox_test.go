@@ -0,0 +1,143 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jung-kurt/gofpdf"
+	"go.uber.org/zap"
+)
+
+// newTextPDF returns a page with the Acumin fonts registered, for measuring text
+func newTextPDF(t *testing.T) *gofpdf.Fpdf {
+	t.Helper()
+	pdf := gofpdf.New("L", "mm", "Letter", "")
+	NewPaperworkPDFService(zap.NewNop(), "../../templates", DefaultBioPolicy, DefaultLayout(), nil).addCustomFonts(pdf)
+	pdf.AddPage()
+	if err := pdf.Error(); err != nil {
+		t.Fatal(err)
+	}
+	return pdf
+}
+
+// layoutText returns the words of a layout as plain text, one string per line
+func layoutText(layout richLayout) []string {
+	lines := make([]string, len(layout.Lines))
+	for i, line := range layout.Lines {
+		words := make([]string, len(line.Words))
+		for j, placed := range line.Words {
+			words[j] = placed.Word.text()
+		}
+		lines[i] = strings.Join(words, " ")
+	}
+	return lines
+}
+
+func TestFitText(t *testing.T) {
+	pdf := newTextPDF(t)
+	box := textBox{Width: 100, Font: "AcuminMedium", MaxFontSize: 14, MinFontSize: 9, FontStep: 0.5, LineSpacing: 1.2}
+	sentences := strings.Repeat("Paints big bold murals in oil. ", 12)
+
+	tests := []struct {
+		name          string
+		text          string
+		height        float64
+		wantSize      float64
+		wantTruncated bool
+		wantLast      string
+	}{
+		{"fits at full size", "Paints big murals.", 20, 14, false, "Paints big murals."},
+		{"shrinks to fit", sentences, 30, 0, false, "Paints big bold murals in oil."},
+		{"cut at a sentence", sentences, 15, 9, true, "..."},
+		{"cut at a word", strings.Repeat("word ", 200), 12, 9, true, "word..."},
+		{"shorter than a line", "Paints big murals.", 1, 9, true, "..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box := box
+			box.Height = tt.height
+			fit := fitText(pdf, parseMarkdown(tt.text), box)
+
+			if fit.Truncated != tt.wantTruncated {
+				t.Errorf("truncated = %v, want %v", fit.Truncated, tt.wantTruncated)
+			}
+			if tt.wantSize != 0 && fit.Layout.FontSize != tt.wantSize {
+				t.Errorf("font size = %v, want %v", fit.Layout.FontSize, tt.wantSize)
+			}
+			if tt.wantSize == 0 && (fit.Layout.FontSize >= box.MaxFontSize || fit.Layout.FontSize < box.MinFontSize) {
+				t.Errorf("font size = %v, want a step between %v and %v", fit.Layout.FontSize, box.MinFontSize, box.MaxFontSize)
+			}
+			if h := fit.Layout.height(); h > tt.height && len(fit.Layout.Lines) > 1 {
+				t.Errorf("%d lines are %.1fmm tall, past the %.1fmm box", len(fit.Layout.Lines), h, tt.height)
+			}
+			lines := layoutText(fit.Layout)
+			if len(lines) == 0 || !strings.HasSuffix(lines[len(lines)-1], tt.wantLast) {
+				t.Errorf("lines = %q, want the last to end with %q", lines, tt.wantLast)
+			}
+		})
+	}
+
+	// Shrinking uses the largest step that fits
+	fit := fitText(pdf, parseMarkdown(sentences), textBox{Width: 100, Height: 30, Font: "AcuminMedium", MaxFontSize: 14, MinFontSize: 9, FontStep: 0.5, LineSpacing: 1.2})
+	bigger := box.layout(pdf, parseMarkdown(sentences), fit.Layout.FontSize+0.5)
+	if bigger.height() <= 30 {
+		t.Errorf("fitted at %vpt, but %vpt also fits", fit.Layout.FontSize, fit.Layout.FontSize+0.5)
+	}
+
+	// A box shorter than one line holds only the ellipsis
+	fit = fitText(pdf, parseMarkdown("Paints big murals."), textBox{Width: 100, Height: 1, Font: "AcuminMedium", MaxFontSize: 14, MinFontSize: 9, LineSpacing: 1.2})
+	if lines := layoutText(fit.Layout); len(lines) != 1 || lines[0] != "..." {
+		t.Errorf("lines = %q, want a lone ellipsis", lines)
+	}
+}
+
+func TestTruncateWords(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		max           int
+		want          string
+		wantTruncated bool
+	}{
+		{"under budget", "Paints big murals.", 50, "Paints big murals.", false},
+		{"exactly the budget", "Paints big murals.", 18, "Paints big murals.", false},
+		{"cut at a word", "Paints big murals.", 12, "Paints big...", true},
+		{"trailing punctuation replaced", "Paints, big murals.", 8, "Paints...", true},
+		{"first word always kept", "Extraordinarily big", 5, "Extraordinarily...", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			words, truncated := truncateWords(parseMarkdown(tt.text), tt.max)
+			if got := richString(words); got != tt.want || truncated != tt.wantTruncated {
+				t.Errorf("truncateWords(%q, %d) = %q, %v; want %q, %v", tt.text, tt.max, got, truncated, tt.want, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestBioLayoutBudget(t *testing.T) {
+	pdf := newTextPDF(t)
+	bio := strings.Repeat("Paints big bold murals in oil. ", 20)
+
+	full := bioLayout(pdf, bio, "AcuminMedium", 10, 120, 5, BioBudget{})
+	if len(full.Lines) < 5 {
+		t.Fatalf("unbudgeted bio has %d lines, want enough to truncate", len(full.Lines))
+	}
+
+	lines := layoutText(bioLayout(pdf, bio, "AcuminMedium", 10, 120, 5, BioBudget{MaxLines: 3}))
+	if len(lines) != 3 || !strings.HasSuffix(lines[2], "...") {
+		t.Errorf("MaxLines 3: lines = %q, want 3 ending with an ellipsis", lines)
+	}
+
+	lines = layoutText(bioLayout(pdf, bio, "AcuminMedium", 10, 120, 5, BioBudget{MaxChars: 40}))
+	if text := strings.Join(lines, " "); len(text) > 43 || !strings.HasSuffix(text, "...") {
+		t.Errorf("MaxChars 40: text = %q, want at most 40 characters and an ellipsis", text)
+	}
+
+	lines = layoutText(bioLayout(pdf, "Short bio.", "AcuminMedium", 10, 120, 5, BioBudget{MaxChars: 40, MaxLines: 3}))
+	if len(lines) != 1 || lines[0] != "Short bio." {
+		t.Errorf("short bio: lines = %q, want it untouched", lines)
+	}
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"paperwork-service/internal/models"
+)
+
+// Profile controls how much contact and payment detail a rendering may show
+type Profile string
+
+const (
+	// ProfileStaff shows everything
+	ProfileStaff Profile = "staff"
+	// ProfileVolunteer masks emails and phone numbers
+	ProfileVolunteer Profile = "volunteer"
+	// ProfilePublic removes bidder identity, payment status and artist contact details
+	ProfilePublic Profile = "public"
+)
+
+// ParseProfile parses a profile name; an empty string selects the given default
+func ParseProfile(raw string, defaultProfile Profile) (Profile, error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if raw == "" {
+		return defaultProfile, nil
+	}
+
+	switch profile := Profile(raw); profile {
+	case ProfileStaff, ProfileVolunteer, ProfilePublic:
+		return profile, nil
+	default:
+		return "", fmt.Errorf("unknown profile %q (valid profiles: staff, volunteer, public)", raw)
+	}
+}
+
+// ShowsBidders reports whether bidder and payment columns are printed for this profile
+func (p Profile) ShowsBidders() bool {
+	return p != ProfilePublic
+}
+
+// ApplyProfile redacts the data for the given profile. The data is modified in place.
+func ApplyProfile(data *PaperworkData, profile Profile) {
+	data.Artists = redactArtists(data.Artists, profile)
+	data.AuctionLots = redactLots(data.AuctionLots, profile)
+}
+
+// redactArtists returns a copy of the artists with contact details redacted for the profile
+func redactArtists(artists []models.EventArtist, profile Profile) []models.EventArtist {
+	redacted := append([]models.EventArtist(nil), artists...)
+	if profile == ProfileStaff {
+		return redacted
+	}
+
+	for i := range redacted {
+		artist := &redacted[i]
+		switch profile {
+		case ProfileVolunteer:
+			artist.Email = maskEmail(artist.Email)
+			artist.Phone = maskPhone(artist.Phone)
+		default:
+			artist.Email = ""
+			artist.Phone = ""
+		}
+	}
+	return redacted
+}
+
+// redactLots returns a copy of the auction lots with bidder details redacted for the profile.
+// Bids are copied too, so the caller's data is never modified.
+func redactLots(lots []models.AuctionLot, profile Profile) []models.AuctionLot {
+	redacted := append([]models.AuctionLot(nil), lots...)
+
+	for i := range redacted {
+		lot := &redacted[i]
+		if lot.WinningBid != nil {
+			bid := redactBid(*lot.WinningBid, profile)
+			lot.WinningBid = &bid
+		}
+		if lot.AllBids != nil {
+			bids := make([]models.Bid, len(lot.AllBids))
+			for j, bid := range lot.AllBids {
+				bids[j] = redactBid(bid, profile)
+			}
+			lot.AllBids = bids
+		}
+	}
+	return redacted
+}
+
+// redactBid redacts one bid for the profile
+func redactBid(bid models.Bid, profile Profile) models.Bid {
+	switch profile {
+	case ProfileStaff:
+	case ProfileVolunteer:
+		bid.BidderEmail = maskEmail(bid.BidderEmail)
+		bid.BidderPhone = maskPhone(bid.BidderPhone)
+	default:
+		// Anything other than staff or volunteer gets the public treatment
+		bid.BidderID = ""
+		bid.BidderName = ""
+		bid.BidderEmail = ""
+		bid.BidderPhone = ""
+		bid.PaymentStatus = ""
+	}
+	return bid
+}
+
+// maskEmail keeps the first character and the domain, e.g. j***@gmail.com
+func maskEmail(email string) string {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return ""
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return "***"
+	}
+	first := []rune(email[:at])[0]
+	return string(first) + "***" + email[at:]
+}
+
+// maskPhone keeps only the last four digits, e.g. ***-1234
+func maskPhone(phone string) string {
+	digits := []rune{}
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			digits = append(digits, r)
+		}
+	}
+	if len(digits) == 0 {
+		return ""
+	}
+	if len(digits) < 4 {
+		return "***"
+	}
+	return "***-" + string(digits[len(digits)-4:])
+}
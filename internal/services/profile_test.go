@@ -0,0 +1,281 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"paperwork-service/internal/models"
+
+	"go.uber.org/zap"
+)
+
+// pdfStreamPattern finds every stream body in a PDF file
+var pdfStreamPattern = regexp.MustCompile(`(?s)stream\r?\n(.*?)\r?\nendstream`)
+
+// pdfContent returns every stream of a PDF, inflated where compressed, so tests can look
+// for the text that was drawn
+func pdfContent(t *testing.T, pdf []byte) []byte {
+	t.Helper()
+	var content []byte
+	for _, match := range pdfStreamPattern.FindAllSubmatch(pdf, -1) {
+		stream := match[1]
+		if r, err := zlib.NewReader(bytes.NewReader(stream)); err == nil {
+			if inflated, err := io.ReadAll(r); err == nil {
+				stream = inflated
+			}
+		}
+		content = append(content, stream...)
+		content = append(content, '\n')
+	}
+	if len(content) == 0 {
+		t.Fatal("PDF has no content streams")
+	}
+	return content
+}
+
+// pdfCount counts how often text was drawn, whether in a UTF-16 (TrueType) or a
+// single-byte (core font) string
+func pdfCount(content []byte, text string) int {
+	var utf16 []byte
+	for _, r := range text {
+		utf16 = append(utf16, byte(r>>8), byte(r))
+	}
+	return bytes.Count(content, utf16) + bytes.Count(content, []byte(text))
+}
+
+// xlsxContent returns every part of an .xlsx package concatenated
+func xlsxContent(t *testing.T, workbook []byte) string {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(workbook), int64(len(workbook)))
+	if err != nil {
+		t.Fatalf("workbook is not a ZIP: %v", err)
+	}
+	var content strings.Builder
+	for _, f := range archive.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(rc)
+		rc.Close()
+		content.Write(body)
+	}
+	return content.String()
+}
+
+// piiEvent is an event whose artists and bidders have distinctive contact details
+func piiEvent() *PaperworkData {
+	start := time.Date(2025, 3, 14, 19, 0, 0, 0, time.UTC)
+	event := models.Event{ID: "event-1", EID: "AB4000", Name: "Redaction Night", Currency: "CAD", EventStartDatetime: start}
+	artists := []models.EventArtist{
+		{EntryID: 1, RoundNumber: 1, EaselNumber: 1, ArtistName: "Alma Artist", Email: "alma.artist@private.test", Phone: "+1 416 555 0142"},
+		{EntryID: 2, RoundNumber: 1, EaselNumber: 2, ArtistName: "Bo Brush", Email: "bo.brush@private.test", Phone: "(604) 555-0177"},
+	}
+	wendy := models.Bid{ID: "bid-2", Round: 1, EaselNumber: 1, Amount: 150, IsWinning: true, BidTime: start.Add(40 * time.Minute),
+		BidderID: "person-wendy", BidderName: "Wendy Winner", BidderEmail: "wendy.winner@private.test", BidderPhone: "647-555-0199", PaymentStatus: "paid"}
+	anonymous := models.Bid{ID: "bid-3", Round: 1, EaselNumber: 2, Amount: 90, IsWinning: true, BidTime: start.Add(45 * time.Minute),
+		BidderID: "person-anon", BidderEmail: "quiet.bidder@private.test", BidderPhone: "905-555-0123", PaymentStatus: "pending"}
+	lots := []models.AuctionLot{
+		{Round: 1, EaselNumber: 1, ArtistName: "Alma Artist", BidCount: 2, HighestBid: 150, WinningBid: &wendy, AllBids: []models.Bid{
+			{ID: "bid-1", Round: 1, EaselNumber: 1, Amount: 100, BidTime: start.Add(35 * time.Minute),
+				BidderID: "person-ray", BidderName: "Ray Runnerup", BidderEmail: "ray.runnerup@private.test", BidderPhone: "416-555-0111"},
+			wendy,
+		}},
+		{Round: 1, EaselNumber: 2, ArtistName: "Bo Brush", BidCount: 1, HighestBid: 90, WinningBid: &anonymous, AllBids: []models.Bid{anonymous}},
+	}
+	return &PaperworkData{Event: event, Artists: artists, AuctionLots: lots}
+}
+
+// contactDetails are every email and phone number in piiEvent
+var contactDetails = []string{
+	"alma.artist@private.test", "+1 416 555 0142",
+	"bo.brush@private.test", "(604) 555-0177",
+	"wendy.winner@private.test", "647-555-0199",
+	"quiet.bidder@private.test", "905-555-0123",
+	"ray.runnerup@private.test", "416-555-0111",
+}
+
+// bidderNames are the bidder names in piiEvent
+var bidderNames = []string{"Wendy Winner", "Ray Runnerup"}
+
+// renderAllFormats renders piiEvent as a PDF with every section, both CSVs and a workbook
+func renderAllFormats(t *testing.T, profile Profile) (pdf []byte, csv string, xlsx string) {
+	t.Helper()
+	data := piiEvent()
+	s := NewPaperworkPDFService(zap.NewNop(), "../../templates", DefaultBioPolicy, DefaultLayout(), nil)
+
+	pdfData, err := s.GenerateEventPaperworkContext(context.Background(), &data.Event, data.Artists, data.AuctionLots, RenderOptions{
+		Sections: []Section{SectionArtistList, SectionAuction, SectionBios, SectionArtistPages, SectionBidHistory},
+		Profile:  profile,
+	})
+	if err != nil {
+		t.Fatalf("PDF: %v", err)
+	}
+
+	var roster, auction, workbook bytes.Buffer
+	if err := WriteRosterCSV(&roster, data.Artists, CSVOptions{Profile: profile}); err != nil {
+		t.Fatalf("roster CSV: %v", err)
+	}
+	if err := WriteAuctionCSV(&auction, &data.Event, data.Artists, data.AuctionLots, CSVOptions{Profile: profile}); err != nil {
+		t.Fatalf("auction CSV: %v", err)
+	}
+	if err := WriteEventWorkbook(&workbook, data, XLSXOptions{Profile: profile}); err != nil {
+		t.Fatalf("workbook: %v", err)
+	}
+
+	return pdfContent(t, pdfData), roster.String() + auction.String(), xlsxContent(t, workbook.Bytes())
+}
+
+func TestProfilesNeverLeakContactDetails(t *testing.T) {
+	for _, profile := range []Profile{ProfileVolunteer, ProfilePublic} {
+		t.Run(string(profile), func(t *testing.T) {
+			pdf, csv, xlsx := renderAllFormats(t, profile)
+
+			leaks := contactDetails
+			if profile == ProfilePublic {
+				leaks = append(append([]string(nil), leaks...), bidderNames...)
+			}
+			for _, text := range leaks {
+				if n := pdfCount(pdf, text); n > 0 {
+					t.Errorf("PDF shows %q %d times", text, n)
+				}
+				if strings.Contains(csv, text) {
+					t.Errorf("CSV shows %q", text)
+				}
+				if strings.Contains(xlsx, text) {
+					t.Errorf("XLSX shows %q", text)
+				}
+			}
+		})
+	}
+
+	// The same checks find the details when the staff profile shows them
+	pdf, csv, xlsx := renderAllFormats(t, ProfileStaff)
+	if pdfCount(pdf, "Wendy Winner") == 0 || !strings.Contains(csv, "Wendy Winner") {
+		t.Error("staff PDF or CSV is missing the winning bidder")
+	}
+	if !strings.Contains(csv, "quiet.bidder@private.test") {
+		t.Error("staff auction CSV is missing the bidder email fallback")
+	}
+	for _, text := range contactDetails[4:] {
+		if !strings.Contains(xlsx, text) {
+			t.Errorf("staff XLSX is missing %q", text)
+		}
+	}
+}
+
+func TestVolunteerProfileMasksContactDetails(t *testing.T) {
+	data := piiEvent()
+	ApplyProfile(data, ProfileVolunteer)
+
+	if got := data.Artists[0].Email; got != "a***@private.test" {
+		t.Errorf("artist email = %q", got)
+	}
+	if got := data.Artists[1].Phone; got != "***-0177" {
+		t.Errorf("artist phone = %q", got)
+	}
+	winning := data.AuctionLots[0].WinningBid
+	if winning.BidderName != "Wendy Winner" || winning.BidderEmail != "w***@private.test" || winning.BidderPhone != "***-0199" || winning.PaymentStatus != "paid" {
+		t.Errorf("winning bid = %+v", *winning)
+	}
+}
+
+func TestPublicProfileDropsBidderIdentity(t *testing.T) {
+	data := piiEvent()
+	ApplyProfile(data, ProfilePublic)
+
+	for _, artist := range data.Artists {
+		if artist.Email != "" || artist.Phone != "" {
+			t.Errorf("artist %s keeps contact details: %q %q", artist.ArtistName, artist.Email, artist.Phone)
+		}
+	}
+	for _, lot := range data.AuctionLots {
+		for _, bid := range append([]models.Bid{*lot.WinningBid}, lot.AllBids...) {
+			if bid.BidderID != "" || bid.BidderName != "" || bid.BidderEmail != "" || bid.BidderPhone != "" || bid.PaymentStatus != "" {
+				t.Errorf("bid %s keeps bidder details: %+v", bid.ID, bid)
+			}
+			if bid.Amount == 0 {
+				t.Errorf("bid %s lost its amount", bid.ID)
+			}
+		}
+	}
+}
+
+func TestRedactionLeavesInputUntouched(t *testing.T) {
+	data := piiEvent()
+	before := piiEvent()
+
+	for _, profile := range []Profile{ProfileVolunteer, ProfilePublic, Profile("unknown")} {
+		redactArtists(data.Artists, profile)
+		redactLots(data.AuctionLots, profile)
+	}
+	if !reflect.DeepEqual(data, before) {
+		t.Error("redaction modified the caller's data")
+	}
+
+	// Unknown profiles get the public treatment rather than leaking
+	lots := redactLots(data.AuctionLots, Profile("unknown"))
+	if lots[0].WinningBid.BidderEmail != "" || lots[0].AllBids[0].BidderName != "" {
+		t.Error("unknown profile kept bidder details")
+	}
+}
+
+func TestMaskEmail(t *testing.T) {
+	tests := map[string]string{
+		"":                       "",
+		"jane.doe@gmail.com":     "j***@gmail.com",
+		"  j@example.org ":       "j***@example.org",
+		"élodie@exemple.fr":      "é***@exemple.fr",
+		"odd@name@example.com":   "o***@example.com",
+		"no-at-sign.example.com": "***",
+		"@example.com":           "***",
+	}
+	for email, want := range tests {
+		if got := maskEmail(email); got != want {
+			t.Errorf("maskEmail(%q) = %q, want %q", email, got, want)
+		}
+	}
+}
+
+func TestMaskPhone(t *testing.T) {
+	tests := map[string]string{
+		"":                  "",
+		"+1 (416) 555-1234": "***-1234",
+		"4165551234":        "***-1234",
+		"ext 12":            "***",
+		"n/a":               "",
+	}
+	for phone, want := range tests {
+		if got := maskPhone(phone); got != want {
+			t.Errorf("maskPhone(%q) = %q, want %q", phone, got, want)
+		}
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    Profile
+		wantErr bool
+	}{
+		{"", ProfilePublic, false},
+		{"staff", ProfileStaff, false},
+		{" Volunteer ", ProfileVolunteer, false},
+		{"PUBLIC", ProfilePublic, false},
+		{"admin", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseProfile(tt.raw, ProfilePublic)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseProfile(%q) = %q, %v", tt.raw, got, err)
+		}
+	}
+}
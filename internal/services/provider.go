@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"go.uber.org/zap"
+)
+
+// EventDataProvider loads the data an event's paperwork is built from. Failures wrap
+// ErrEventNotFound, ErrUpstreamUnavailable or ErrUpstreamBadData, except cancellation,
+// which returns the context's error.
+type EventDataProvider interface {
+	GetEventPaperworkData(ctx context.Context, eid string) (*PaperworkData, error)
+	// Name identifies the provider in logs, e.g. "edge"
+	Name() string
+}
+
+// Name identifies the edge function provider in logs
+func (s *EventService) Name() string {
+	return "edge"
+}
+
+// eidPattern matches well-formed event EIDs such as AB2995
+var eidPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidEID reports whether eid is a well-formed event EID: letters, digits, '-' and '_'.
+// EIDs end up in URLs, file names and ZIP entries, so anything else is rejected.
+func ValidEID(eid string) bool {
+	return eidPattern.MatchString(eid)
+}
+
+// FixtureProvider reads event data from <EID>.json files in a local directory, in the edge
+// function's response format. It lets producers print at venues without a connection and
+// gives tests a fixed data set.
+type FixtureProvider struct {
+	logger *zap.Logger
+	dir    string
+}
+
+// NewFixtureProvider creates a provider for the fixture files in dir
+func NewFixtureProvider(logger *zap.Logger, dir string) *FixtureProvider {
+	return &FixtureProvider{logger: logger, dir: dir}
+}
+
+// Name identifies the fixtures provider in logs
+func (p *FixtureProvider) Name() string {
+	return "fixtures"
+}
+
+// GetEventPaperworkData reads the fixture for eid
+func (p *FixtureProvider) GetEventPaperworkData(ctx context.Context, eid string) (*PaperworkData, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Plain EIDs only, so a request can't read outside the directory
+	if !ValidEID(eid) {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotFound, eid)
+	}
+
+	path := filepath.Join(p.dir, eid+".json")
+	body, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotFound, eid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUpstreamUnavailable, err)
+	}
+
+	var data PaperworkData
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse %s: %w", ErrUpstreamBadData, path, err)
+	}
+
+	p.logger.Info("Loaded paperwork data from fixture",
+		zap.String("eid", eid),
+		zap.String("path", path),
+		zap.String("event_name", data.Event.Name))
+
+	return &data, nil
+}
+
+// ChainedProvider tries each provider in order and returns the first success, so a local
+// source can stand in while the edge function is down
+type ChainedProvider struct {
+	logger    *zap.Logger
+	providers []EventDataProvider
+}
+
+// NewChainedProvider creates a provider that falls back through providers in order
+func NewChainedProvider(logger *zap.Logger, providers ...EventDataProvider) *ChainedProvider {
+	return &ChainedProvider{logger: logger, providers: providers}
+}
+
+// Name lists the chained providers in logs, e.g. "edge,fixtures"
+func (c *ChainedProvider) Name() string {
+	name := ""
+	for i, provider := range c.providers {
+		if i > 0 {
+			name += ","
+		}
+		name += provider.Name()
+	}
+	return name
+}
+
+// GetEventPaperworkData returns the first provider's data that loads. If every provider
+// fails, the first error other than ErrEventNotFound is returned, so an outage is not
+// reported as a missing event just because a fallback has no copy of it.
+func (c *ChainedProvider) GetEventPaperworkData(ctx context.Context, eid string) (*PaperworkData, error) {
+	var firstErr, notFound error
+	for _, provider := range c.providers {
+		data, err := provider.GetEventPaperworkData(ctx, eid)
+		if err == nil {
+			return data, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		c.logger.Warn("Event data provider failed, trying the next one",
+			zap.String("eid", eid),
+			zap.String("provider", provider.Name()),
+			zap.Error(err))
+		if errors.Is(err, ErrEventNotFound) {
+			notFound = err
+		} else if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if notFound != nil {
+		return nil, notFound
+	}
+	return nil, fmt.Errorf("%w: no event data providers configured", ErrUpstreamUnavailable)
+}
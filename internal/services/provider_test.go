@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestFixtureProvider(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "AB4000.json"), []byte(`{"event": {"eid": "AB4000", "name": "Big Night"}, "total_artists": 2}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "AB4001.json"), []byte(`{"event": `), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// A file outside the directory that a crafted EID could reach
+	if err := os.WriteFile(filepath.Join(filepath.Dir(dir), "secret.json"), []byte(`{}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p := NewFixtureProvider(zap.NewNop(), dir)
+
+	data, err := p.GetEventPaperworkData(context.Background(), "AB4000")
+	if err != nil || data.Event.Name != "Big Night" || data.TotalArtists != 2 {
+		t.Fatalf("GetEventPaperworkData = %+v, %v", data, err)
+	}
+
+	tests := []struct {
+		eid  string
+		want error
+	}{
+		{"AB9999", ErrEventNotFound},
+		{"AB4001", ErrUpstreamBadData},
+		{"../secret", ErrEventNotFound},
+		{"", ErrEventNotFound},
+	}
+	for _, tt := range tests {
+		if _, err := p.GetEventPaperworkData(context.Background(), tt.eid); !errors.Is(err, tt.want) {
+			t.Errorf("%q: err = %v, want %v", tt.eid, err, tt.want)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.GetEventPaperworkData(ctx, "AB4000"); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled: err = %v", err)
+	}
+}
+
+// chainStub answers with data named after it, or err, and counts its calls
+type chainStub struct {
+	name  string
+	err   error
+	calls int
+}
+
+func (s *chainStub) Name() string {
+	return s.name
+}
+
+func (s *chainStub) GetEventPaperworkData(ctx context.Context, eid string) (*PaperworkData, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	data := &PaperworkData{}
+	data.Event.Name = s.name
+	return data, nil
+}
+
+func TestChainedProvider(t *testing.T) {
+	notFound := fmt.Errorf("%w: AB4000", ErrEventNotFound)
+	unavailable := fmt.Errorf("%w: status 503", ErrUpstreamUnavailable)
+	badData := fmt.Errorf("%w: bad JSON", ErrUpstreamBadData)
+
+	tests := []struct {
+		name      string
+		stubs     []*chainStub
+		wantData  string
+		wantErr   error
+		wantCalls []int
+	}{
+		{"first success wins", []*chainStub{{name: "edge"}, {name: "fixtures"}}, "edge", nil, []int{1, 0}},
+		{"falls through on not found", []*chainStub{{name: "edge", err: notFound}, {name: "fixtures"}}, "fixtures", nil, []int{1, 1}},
+		{"falls through on an outage", []*chainStub{{name: "edge", err: unavailable}, {name: "postgrest", err: badData}, {name: "fixtures"}}, "fixtures", nil, []int{1, 1, 1}},
+		{"outage beats a later not found", []*chainStub{{name: "edge", err: unavailable}, {name: "fixtures", err: notFound}}, "", ErrUpstreamUnavailable, []int{1, 1}},
+		{"outage beats an earlier not found", []*chainStub{{name: "fixtures", err: notFound}, {name: "edge", err: unavailable}}, "", ErrUpstreamUnavailable, []int{1, 1}},
+		{"first outage beats later ones", []*chainStub{{name: "edge", err: badData}, {name: "postgrest", err: unavailable}}, "", ErrUpstreamBadData, []int{1, 1}},
+		{"not found everywhere", []*chainStub{{name: "edge", err: notFound}, {name: "fixtures", err: notFound}}, "", ErrEventNotFound, []int{1, 1}},
+		{"no providers", nil, "", ErrUpstreamUnavailable, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providers := make([]EventDataProvider, len(tt.stubs))
+			for i, stub := range tt.stubs {
+				providers[i] = stub
+			}
+			data, err := NewChainedProvider(zap.NewNop(), providers...).GetEventPaperworkData(context.Background(), "AB4000")
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil || data.Event.Name != tt.wantData {
+				t.Errorf("got data from %v, %v, want %s", data, err, tt.wantData)
+			}
+			for i, stub := range tt.stubs {
+				if stub.calls != tt.wantCalls[i] {
+					t.Errorf("%s called %d times, want %d", stub.name, stub.calls, tt.wantCalls[i])
+				}
+			}
+		})
+	}
+}
+
+func TestChainedProviderStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	first := &chainStub{name: "edge", err: context.Canceled}
+	second := &chainStub{name: "fixtures"}
+	cancel()
+
+	_, err := NewChainedProvider(zap.NewNop(), first, second).GetEventPaperworkData(ctx, "AB4000")
+	if !errors.Is(err, context.Canceled) || second.calls != 0 {
+		t.Errorf("err = %v, fallback called %d times; want the cancellation and no fallback", err, second.calls)
+	}
+}
+
+func TestChainedProviderName(t *testing.T) {
+	c := NewChainedProvider(zap.NewNop(), &chainStub{name: "edge"}, &chainStub{name: "fixtures"})
+	if c.Name() != "edge,fixtures" {
+		t.Errorf("Name = %q", c.Name())
+	}
+}
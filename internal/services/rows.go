@@ -0,0 +1,259 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"paperwork-service/internal/models"
+)
+
+// RosterRow is one line of the artist list, shared by the PDF and the exports
+type RosterRow struct {
+	RoundEasel  string
+	RoundNumber int
+	EaselNumber int
+	ArtistName  string
+}
+
+// AuctionRow is one line of the auction table, shared by the PDF and the exports
+type AuctionRow struct {
+	ID            string // EID-round-easel
+	RoundNumber   int
+	EaselNumber   int
+	ArtistName    string
+	BidCount      int
+	TopBid        float64 // 0 when there are no bids
+	Bidder        string  // winning bidder name, falling back to email
+	PaymentStatus string
+}
+
+// BidHistoryLot is the full bid list for one auction lot
+type BidHistoryLot struct {
+	ID          string // EID-round-easel
+	RoundNumber int
+	EaselNumber int
+	ArtistName  string
+	Bids        []BidHistoryRow
+}
+
+// BidHistoryRow is one bid in a lot's history, oldest first
+type BidHistoryRow struct {
+	Time      time.Time
+	Bidder    string // masked bidder label, "-" when the profile hides bidders
+	Amount    float64
+	Increment float64 // over the previous bid; 0 for the opening bid
+	Winning   bool
+	Bid       models.Bid // the bid itself, for exports that list full bidder details
+}
+
+// BuildRosterRows returns the artist list rows for ready artists, in artist order
+func BuildRosterRows(artists []models.EventArtist) []RosterRow {
+	rows := []RosterRow{}
+	for _, artist := range artists {
+		// Skip confirmed-only artists
+		if artist.Status == "confirmed-only" {
+			continue
+		}
+
+		rows = append(rows, RosterRow{
+			RoundEasel:  fmt.Sprintf("%d-%d", artist.RoundNumber, artist.EaselNumber),
+			RoundNumber: artist.RoundNumber,
+			EaselNumber: artist.EaselNumber,
+			ArtistName:  resolveArtistName(artist),
+		})
+	}
+	return rows
+}
+
+// BuildAuctionRows returns one auction row per ready artist, joined to its lot by round and easel
+func BuildAuctionRows(eventEID string, artists []models.EventArtist, auctionLots []models.AuctionLot) []AuctionRow {
+	// Create a map of auction lots by round and easel for quick lookup
+	lotMap := make(map[string]models.AuctionLot)
+	for _, lot := range auctionLots {
+		lotMap[easelKey(lot.Round, lot.EaselNumber)] = lot
+	}
+
+	rows := []AuctionRow{}
+	for _, artist := range artists {
+		if artist.Status == "confirmed-only" {
+			continue
+		}
+
+		row := AuctionRow{
+			ID:          fmt.Sprintf("%s-%d-%d", eventEID, artist.RoundNumber, artist.EaselNumber),
+			RoundNumber: artist.RoundNumber,
+			EaselNumber: artist.EaselNumber,
+			ArtistName:  resolveArtistName(artist),
+		}
+
+		// Look up auction data
+		if lot, hasLot := lotMap[easelKey(artist.RoundNumber, artist.EaselNumber)]; hasLot {
+			row.BidCount = lot.BidCount
+			if lot.HighestBid > 0 {
+				row.TopBid = lot.HighestBid
+			}
+			if lot.WinningBid != nil {
+				row.Bidder = lot.WinningBid.BidderName
+				if row.Bidder == "" {
+					row.Bidder = lot.WinningBid.BidderEmail
+				}
+				row.PaymentStatus = lot.WinningBid.PaymentStatus
+			}
+		}
+
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// BuildBidHistory returns the bid history of every lot that has bids, in lot order.
+// Bids are sorted by time; bidders are always masked, whatever the profile allows.
+func BuildBidHistory(eventEID string, artists []models.EventArtist, auctionLots []models.AuctionLot) []BidHistoryLot {
+	// Fall back to the roster for lots that were not joined to an artist
+	names := make(map[string]string)
+	for _, artist := range artists {
+		key := easelKey(artist.RoundNumber, artist.EaselNumber)
+		if artist.Status == "confirmed-only" || names[key] != "" {
+			continue
+		}
+		names[key] = resolveArtistName(artist)
+	}
+
+	history := []BidHistoryLot{}
+	for _, lot := range auctionLots {
+		if len(lot.AllBids) == 0 {
+			continue
+		}
+
+		entry := BidHistoryLot{
+			ID:          fmt.Sprintf("%s-%d-%d", eventEID, lot.Round, lot.EaselNumber),
+			RoundNumber: lot.Round,
+			EaselNumber: lot.EaselNumber,
+			ArtistName:  lot.ArtistName,
+			Bids:        make([]BidHistoryRow, 0, len(lot.AllBids)),
+		}
+		if entry.ArtistName == "" {
+			entry.ArtistName = names[easelKey(lot.Round, lot.EaselNumber)]
+		}
+
+		bids := append([]models.Bid(nil), lot.AllBids...)
+		sort.SliceStable(bids, func(i, j int) bool {
+			if !bids[i].BidTime.Equal(bids[j].BidTime) {
+				return bids[i].BidTime.Before(bids[j].BidTime)
+			}
+			return bids[i].Amount < bids[j].Amount
+		})
+
+		previous := 0.0
+		for i, bid := range bids {
+			row := BidHistoryRow{
+				Time:    bid.BidTime,
+				Bidder:  maskBidder(bid),
+				Amount:  bid.Amount,
+				Winning: bid.IsWinning || (lot.WinningBid != nil && lot.WinningBid.ID != "" && bid.ID == lot.WinningBid.ID),
+				Bid:     bid,
+			}
+			if i > 0 {
+				row.Increment = bid.Amount - previous
+			}
+			previous = bid.Amount
+			entry.Bids = append(entry.Bids, row)
+		}
+
+		history = append(history, entry)
+	}
+	return history
+}
+
+// maskBidder labels a bidder by first name, last initial and the last digits of their phone,
+// e.g. "Jane S. ***-1234", which is enough for the auction desk to match the auction system
+func maskBidder(bid models.Bid) string {
+	parts := []string{}
+
+	fields := strings.Fields(bid.BidderName)
+	switch len(fields) {
+	case 0:
+	case 1:
+		parts = append(parts, fields[0])
+	default:
+		last := []rune(fields[len(fields)-1])
+		parts = append(parts, fmt.Sprintf("%s %s.", fields[0], string(last[0])))
+	}
+
+	if phone := maskPhone(bid.BidderPhone); phone != "" {
+		parts = append(parts, phone)
+	} else if len(parts) == 0 && bid.BidderEmail != "" {
+		parts = append(parts, maskEmail(bid.BidderEmail))
+	}
+
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
+
+// currencySymbol maps an ISO currency code to the symbol printed on the paperwork
+func currencySymbol(currency string) string {
+	switch currency {
+	case "EUR":
+		return "€"
+	case "GBP":
+		return "£"
+	default:
+		return "$"
+	}
+}
+
+// bioGroup is the set of artists printed under one bio summary title
+type bioGroup struct {
+	Title         string
+	RoundNumber   int  // 0 for artists without a round and for confirmed-only artists
+	ConfirmedOnly bool // confirmed-only artists, who have no easel yet
+	Artists       []models.EventArtist
+}
+
+// groupBios groups artists for the bio summaries: every round in ascending order, labelled
+// from labels when present, then artists with no round, then confirmed-only artists
+func groupBios(artists []models.EventArtist, labels map[int]string) []bioGroup {
+	byRound := make(map[int][]models.EventArtist)
+	rounds := []int{}
+	noRound := []models.EventArtist{}
+	confirmedOnly := []models.EventArtist{}
+
+	for _, artist := range artists {
+		switch {
+		case artist.Status == "confirmed-only":
+			confirmedOnly = append(confirmedOnly, artist)
+		case artist.RoundNumber <= 0:
+			noRound = append(noRound, artist)
+		default:
+			if _, seen := byRound[artist.RoundNumber]; !seen {
+				rounds = append(rounds, artist.RoundNumber)
+			}
+			byRound[artist.RoundNumber] = append(byRound[artist.RoundNumber], artist)
+		}
+	}
+	sort.Ints(rounds)
+
+	groups := []bioGroup{}
+	for _, round := range rounds {
+		label := strings.TrimSpace(labels[round])
+		if label == "" {
+			label = fmt.Sprintf("Round %d", round)
+		}
+		groups = append(groups, bioGroup{
+			Title:       label + " Artist Bios",
+			RoundNumber: round,
+			Artists:     byRound[round],
+		})
+	}
+	if len(noRound) > 0 {
+		groups = append(groups, bioGroup{Title: "Artist Bios - No Round Assigned", Artists: noRound})
+	}
+	if len(confirmedOnly) > 0 {
+		groups = append(groups, bioGroup{Title: "Additional Artist Bios", ConfirmedOnly: true, Artists: confirmedOnly})
+	}
+	return groups
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"paperwork-service/internal/models"
+)
+
+// sampleFirstNames and sampleLastNames are combined into artist names. Some are long or
+// accented on purpose, to show how the layout copes.
+var (
+	sampleFirstNames = []string{
+		"Maximilian Alexander", "Siobhán", "Jean-Baptiste", "Oluwaseun", "María José",
+		"Bartholomew Quincy", "Xiaoming", "Priyanka", "Anastasia", "Kai",
+	}
+	sampleLastNames = []string{
+		"Featherstonehaugh-Worthington", "Ní Mhaoileoin", "Delacroix-Beaumont", "Adebayo-Okonkwo",
+		"Fernández de Córdoba", "Wadsworth III", "Li", "Venkataraghavan", "Konstantinova", "Ng",
+	}
+	sampleBidders = []string{
+		"Alexandra Montgomery-Smythe", "Ben Okafor", "Chloé Tremblay", "Dmitri Volkov",
+		"Eleanor Whitfield", "Farah Haddad", "Gustavo Pereira",
+	}
+
+	// sampleBio is cut to a different length for each artist, from nothing to far too much
+	sampleBio = []string{
+		"**%s** paints large, fast and loud, usually with a palette knife and whatever is left in the tube.",
+		"Born on the coast and trained in the city, they have spent the last decade chasing light across harbours, rooftops and crowded dance floors.",
+		"Their work has hung in _small cafés_ and national galleries alike, and they still cannot decide which audience is tougher.",
+		"Find more at [artbattle.com](https://artbattle.com) or follow along for studio updates.",
+		"They teach a weekly community class, run a mural collective and once painted a portrait on the side of a moving tram.",
+		"Live painting suits them: twenty minutes is long enough to make a mess and short enough to stop before fixing it.",
+		"Recent residencies include a winter in the north, a summer by the sea and a very long autumn in a converted grain elevator.",
+		"They mix their own pigments from clay, ash and crushed shells, which is why the studio smells faintly of the beach.",
+		"Critics have called the work \"restless\", \"generous\" and, on one memorable occasion, \"far too orange\".",
+		"When not painting they can be found on a bicycle, in a second-hand bookshop or arguing about football.",
+	}
+)
+
+// SampleEvent is a built-in synthetic event for previewing layouts without a live event:
+// long names, long and empty bios, four rounds, a confirmed-only artist, long event
+// histories and lots with many bids. It is the same on every call.
+func SampleEvent() (*models.Event, []models.EventArtist, []models.AuctionLot) {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		loc = time.UTC
+	}
+	start := time.Date(2026, time.June, 20, 19, 0, 0, 0, loc)
+
+	event := &models.Event{
+		ID:                 "00000000-0000-0000-0000-000000000000",
+		EID:                "AB9999",
+		Name:               "Art Battle International Championship Finals at the Grand Ballroom",
+		Venue:              "The Grand Ballroom, 1234 Exceptionally Long Street Name Avenue",
+		EventStartDatetime: start,
+		EventEndDatetime:   start.Add(4 * time.Hour),
+		TimezoneIcann:      "America/Toronto",
+		EnableAuction:      true,
+		AuctionStartBid:    50,
+		MinBidIncrement:    10,
+		Currency:           "USD",
+		RoundLabels:        map[int]string{4: "Final Round"},
+	}
+
+	var artists []models.EventArtist
+	var lots []models.AuctionLot
+
+	// Rounds 1-3 have eight easels each and the final round four
+	add := func(round, easel int, status string) {
+		i := len(artists)
+		first := sampleFirstNames[i%len(sampleFirstNames)]
+		last := sampleLastNames[(i*3)%len(sampleLastNames)]
+		name := first + " " + last
+
+		artist := models.EventArtist{
+			ContestantID: fmt.Sprintf("sample-%d", i+1),
+			EaselNumber:  easel,
+			RoundNumber:  round,
+			EventID:      event.ID,
+			EntryID:      100000 + i,
+			ArtistName:   name,
+			Bio:          sampleArtistBio(i, first),
+			PersonName:   name,
+			FirstName:    first,
+			LastName:     last,
+			Email:        fmt.Sprintf("artist%d@example.com", i+1),
+			Phone:        fmt.Sprintf("+1 555 010 %04d", i+1),
+			DisplayName:  name,
+			Status:       status,
+			Round:        round,
+		}
+		if i%4 != 3 {
+			artist.Instagram = "@" + strings.ToLower(strings.Join(strings.Fields(first), ""))
+		}
+		artist.EventHistory = sampleHistory(i)
+		artists = append(artists, artist)
+
+		if round > 0 {
+			lots = append(lots, sampleLot(event, artist, i, start))
+		}
+	}
+	for round := 1; round <= 4; round++ {
+		easels := 8
+		if round == 4 {
+			easels = 4
+		}
+		for easel := 1; easel <= easels; easel++ {
+			add(round, easel, "")
+		}
+	}
+	add(0, 0, "confirmed-only")
+
+	return event, artists, lots
+}
+
+// sampleArtistBio returns a bio of between zero and all of the sample sentences
+func sampleArtistBio(i int, firstName string) string {
+	n := (i * 7) % (len(sampleBio) + 3)
+	if n > len(sampleBio) {
+		n = len(sampleBio) // A few artists get everything, far more than the page holds
+	}
+	sentences := make([]string, 0, n)
+	for j := 0; j < n; j++ {
+		sentence := sampleBio[j]
+		if j == 0 {
+			sentence = fmt.Sprintf(sentence, firstName)
+		}
+		sentences = append(sentences, sentence)
+	}
+	bio := strings.Join(sentences, " ")
+	if n == len(sampleBio) {
+		bio += "\n\n" + bio
+	}
+	return bio
+}
+
+// sampleHistory gives every sixth artist more past events than the artist page lists
+func sampleHistory(i int) []models.ArtistEvent {
+	count := i % 5
+	if i%6 == 0 {
+		count = 24
+	}
+	history := make([]models.ArtistEvent, 0, count)
+	for j := 0; j < count; j++ {
+		history = append(history, models.ArtistEvent{
+			EventEID:    fmt.Sprintf("AB%d", 3000+j*37+i),
+			EventName:   fmt.Sprintf("Art Battle Sample %d", j+1),
+			EventDate:   time.Date(2025-j/6, time.Month(j%12+1), 10, 19, 0, 0, 0, time.UTC),
+			Round:       j%3 + 1,
+			EaselNumber: (i+j)%12 + 1,
+			IsWinner:    (i+j)%4 == 0,
+		})
+	}
+	return history
+}
+
+// sampleLot bids on an artist's painting, from none up to thirty bids
+func sampleLot(event *models.Event, artist models.EventArtist, i int, start time.Time) models.AuctionLot {
+	lot := models.AuctionLot{
+		EventID:     event.ID,
+		Round:       artist.RoundNumber,
+		EaselNumber: artist.EaselNumber,
+		ArtistName:  artist.ArtistName,
+	}
+
+	count := (i * 11) % 31
+	amount := event.AuctionStartBid
+	statuses := []string{"paid", "pending", ""}
+	for n := 0; n < count; n++ {
+		if n > 0 {
+			amount += event.MinBidIncrement + float64((n*i)%3)*12.5
+		}
+		bidder := sampleBidders[(i+n)%len(sampleBidders)]
+		lot.AllBids = append(lot.AllBids, models.Bid{
+			ID:          fmt.Sprintf("sample-bid-%d-%d", i+1, n+1),
+			EventID:     event.ID,
+			Round:       artist.RoundNumber,
+			EaselNumber: artist.EaselNumber,
+			BidderID:    fmt.Sprintf("sample-bidder-%d", (i+n)%len(sampleBidders)+1),
+			Amount:      amount,
+			IsWinning:   n == count-1,
+			BidTime:     start.Add(time.Duration(artist.RoundNumber*30+n*2) * time.Minute),
+			BidderName:  bidder,
+			BidderEmail: fmt.Sprintf("bidder%d@example.com", (i+n)%len(sampleBidders)+1),
+			BidderPhone: fmt.Sprintf("+1 555 020 %04d", (i+n)%len(sampleBidders)+1),
+		})
+	}
+
+	if count > 0 {
+		winning := lot.AllBids[count-1]
+		winning.PaymentStatus = statuses[i%len(statuses)]
+		lot.AllBids[count-1] = winning
+		lot.WinningBid = &winning
+		lot.HighestBid = winning.Amount
+		lot.BidCount = count
+	}
+	return lot
+}
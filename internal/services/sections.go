@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Section identifies one part of the event paperwork pack
+type Section string
+
+const (
+	// SectionArtistList is the round/easel roster table
+	SectionArtistList Section = "artist-list"
+	// SectionAuction is the auction & bidding information table
+	SectionAuction Section = "auction"
+	// SectionBios is the per-round bio summary pages
+	SectionBios Section = "bios"
+	// SectionArtistPages is the individual easel page for every ready artist
+	SectionArtistPages Section = "artist-pages"
+	// SectionBidHistory lists every bid on every lot; optional, not part of DefaultSections
+	SectionBidHistory Section = "bid-history"
+)
+
+// DefaultSections is the full pack, in the order it has always been printed
+var DefaultSections = []Section{
+	SectionArtistList,
+	SectionAuction,
+	SectionBios,
+	SectionArtistPages,
+}
+
+// allSections lists every section a caller may request, defaults first
+var allSections = []Section{
+	SectionArtistList,
+	SectionAuction,
+	SectionBios,
+	SectionArtistPages,
+	SectionBidHistory,
+}
+
+// validSections indexes allSections
+var validSections = map[Section]bool{
+	SectionArtistList:  true,
+	SectionAuction:     true,
+	SectionBios:        true,
+	SectionArtistPages: true,
+	SectionBidHistory:  true,
+}
+
+// ParseSections parses a comma-separated section list such as "auction,artist-pages".
+// An empty string selects DefaultSections. Unknown or repeated names are rejected.
+func ParseSections(raw string) ([]Section, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return DefaultSections, nil
+	}
+
+	seen := make(map[Section]bool)
+	sections := []Section{}
+	for _, part := range strings.Split(raw, ",") {
+		name := Section(strings.ToLower(strings.TrimSpace(part)))
+		if name == "" {
+			continue
+		}
+		if !validSections[name] {
+			return nil, fmt.Errorf("unknown section %q (valid sections: %s)", name, sectionNames(allSections))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("section %q requested more than once", name)
+		}
+		seen[name] = true
+		sections = append(sections, name)
+	}
+
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("no sections requested (valid sections: %s)", sectionNames(allSections))
+	}
+
+	return sections, nil
+}
+
+// sectionNames joins section names for error messages
+func sectionNames(sections []Section) string {
+	names := make([]string, len(sections))
+	for i, section := range sections {
+		names[i] = string(section)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"time"
+	// Embed the zone database; the runtime image ships without tzdata
+	_ "time/tzdata"
+
+	"paperwork-service/internal/models"
+)
+
+// eventLocation returns the event's time zone, falling back to UTC when it is unknown
+func eventLocation(event *models.Event) *time.Location {
+	if event.TimezoneIcann != "" {
+		if loc, err := time.LoadLocation(event.TimezoneIcann); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
@@ -0,0 +1,456 @@
+package services
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"paperwork-service/internal/models"
+)
+
+// Cell style indexes into the cellXfs list written by xlsxStyles
+const (
+	xlsxStyleDefault  = 0
+	xlsxStyleHeader   = 1
+	xlsxStyleCurrency = 2
+	xlsxStyleDateTime = 3
+)
+
+// Static parts of the OOXML package
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`%s</Types>`
+	xlsxSheetOverride = `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`
+	xlsxRootRels      = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	xlsxMainNS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	xlsxRelNS  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	xlsxPkgNS  = "http://schemas.openxmlformats.org/package/2006/relationships"
+)
+
+// xlsxPart is one XML part of the package, encoded with encoding/xml
+type xlsxPart struct {
+	name string
+	v    interface{}
+}
+
+// xlsxSheet is one worksheet before serialization
+type xlsxSheet struct {
+	Name   string
+	Widths []float64
+	Rows   [][]xlsxCell
+}
+
+// xlsxCell is one cell: an inline string (Inline) or a number (Value)
+type xlsxCell struct {
+	XMLName xml.Name          `xml:"c"`
+	Ref     string            `xml:"r,attr"`
+	Style   int               `xml:"s,attr,omitempty"`
+	Type    string            `xml:"t,attr,omitempty"`
+	Value   string            `xml:"v,omitempty"`
+	Inline  *xlsxInlineString `xml:"is,omitempty"`
+}
+
+type xlsxInlineString struct {
+	Text string `xml:"t"`
+}
+
+type xlsxWorksheet struct {
+	XMLName   xml.Name      `xml:"worksheet"`
+	Xmlns     string        `xml:"xmlns,attr"`
+	SheetView xlsxSheetView `xml:"sheetViews>sheetView"`
+	Cols      []xlsxCol     `xml:"cols>col,omitempty"`
+	Rows      []xlsxRow     `xml:"sheetData>row"`
+}
+
+// xlsxSheetView freezes the header row
+type xlsxSheetView struct {
+	WorkbookViewID int      `xml:"workbookViewId,attr"`
+	Pane           xlsxPane `xml:"pane"`
+}
+
+type xlsxPane struct {
+	YSplit      int    `xml:"ySplit,attr"`
+	TopLeftCell string `xml:"topLeftCell,attr"`
+	ActivePane  string `xml:"activePane,attr"`
+	State       string `xml:"state,attr"`
+}
+
+type xlsxCol struct {
+	Min         int     `xml:"min,attr"`
+	Max         int     `xml:"max,attr"`
+	Width       float64 `xml:"width,attr"`
+	CustomWidth int     `xml:"customWidth,attr"`
+}
+
+type xlsxRow struct {
+	R     int        `xml:"r,attr"`
+	Cells []xlsxCell `xml:"c"`
+}
+
+type xlsxWorkbook struct {
+	XMLName xml.Name         `xml:"workbook"`
+	Xmlns   string           `xml:"xmlns,attr"`
+	XmlnsR  string           `xml:"xmlns:r,attr"`
+	Sheets  []xlsxSheetEntry `xml:"sheets>sheet"`
+}
+
+type xlsxSheetEntry struct {
+	Name    string `xml:"name,attr"`
+	SheetID int    `xml:"sheetId,attr"`
+	RID     string `xml:"r:id,attr"`
+}
+
+type xlsxRelationships struct {
+	XMLName       xml.Name           `xml:"Relationships"`
+	Xmlns         string             `xml:"xmlns,attr"`
+	Relationships []xlsxRelationship `xml:"Relationship"`
+}
+
+type xlsxRelationship struct {
+	ID     string `xml:"Id,attr"`
+	Type   string `xml:"Type,attr"`
+	Target string `xml:"Target,attr"`
+}
+
+type xlsxStyleSheet struct {
+	XMLName xml.Name    `xml:"styleSheet"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	NumFmts xlsxNumFmts `xml:"numFmts"`
+	Fonts   xlsxFonts   `xml:"fonts"`
+	Fills   xlsxFills   `xml:"fills"`
+	Borders xlsxBorders `xml:"borders"`
+	CellXfs xlsxCellXfs `xml:"cellXfs"`
+}
+
+type xlsxNumFmts struct {
+	Count   int          `xml:"count,attr"`
+	NumFmts []xlsxNumFmt `xml:"numFmt"`
+}
+
+type xlsxNumFmt struct {
+	ID   int    `xml:"numFmtId,attr"`
+	Code string `xml:"formatCode,attr"`
+}
+
+type xlsxFonts struct {
+	Count int        `xml:"count,attr"`
+	Fonts []xlsxFont `xml:"font"`
+}
+
+type xlsxFont struct {
+	Bold *struct{} `xml:"b,omitempty"`
+	Size xlsxVal   `xml:"sz"`
+	Name xlsxVal   `xml:"name"`
+}
+
+type xlsxVal struct {
+	Val string `xml:"val,attr"`
+}
+
+type xlsxFills struct {
+	Count int        `xml:"count,attr"`
+	Fills []xlsxFill `xml:"fill"`
+}
+
+type xlsxFill struct {
+	Pattern xlsxPatternFill `xml:"patternFill"`
+}
+
+type xlsxPatternFill struct {
+	Type string `xml:"patternType,attr"`
+}
+
+type xlsxBorders struct {
+	Count   int        `xml:"count,attr"`
+	Borders []struct{} `xml:"border"`
+}
+
+type xlsxCellXfs struct {
+	Count int      `xml:"count,attr"`
+	Xfs   []xlsxXf `xml:"xf"`
+}
+
+type xlsxXf struct {
+	NumFmtID          int `xml:"numFmtId,attr"`
+	FontID            int `xml:"fontId,attr"`
+	FillID            int `xml:"fillId,attr"`
+	BorderID          int `xml:"borderId,attr"`
+	ApplyNumberFormat int `xml:"applyNumberFormat,attr,omitempty"`
+	ApplyFont         int `xml:"applyFont,attr,omitempty"`
+}
+
+// XLSXOptions controls workbook export redaction
+type XLSXOptions struct {
+	// Profile controls redaction; empty is treated as public
+	Profile Profile
+}
+
+// WriteEventWorkbook writes an .xlsx workbook with Roster, Auction and Bids sheets.
+// Money cells are numeric with a currency number format.
+func WriteEventWorkbook(w io.Writer, data *PaperworkData, opts XLSXOptions) error {
+	profile := opts.Profile
+	if profile == "" {
+		profile = ProfilePublic
+	}
+	artists := redactArtists(data.Artists, profile)
+	auctionLots := redactLots(data.AuctionLots, profile)
+	loc := eventLocation(&data.Event)
+
+	sheets := []xlsxSheet{
+		rosterSheet(artists),
+		auctionSheet(data.Event.EID, artists, auctionLots, profile.ShowsBidders()),
+		bidsSheet(data.Event.EID, artists, auctionLots, profile.ShowsBidders(), loc),
+	}
+
+	archive := zip.NewWriter(w)
+
+	overrides := make([]string, len(sheets))
+	for i := range sheets {
+		overrides[i] = fmt.Sprintf(xlsxSheetOverride, i+1)
+	}
+	if err := writeZipPart(archive, "[Content_Types].xml", []byte(fmt.Sprintf(xlsxContentTypes, strings.Join(overrides, "")))); err != nil {
+		return err
+	}
+	if err := writeZipPart(archive, "_rels/.rels", []byte(xlsxRootRels)); err != nil {
+		return err
+	}
+
+	workbook := xlsxWorkbook{Xmlns: xlsxMainNS, XmlnsR: xlsxRelNS}
+	rels := xlsxRelationships{Xmlns: xlsxPkgNS}
+	for i, sheet := range sheets {
+		rID := fmt.Sprintf("rId%d", i+1)
+		workbook.Sheets = append(workbook.Sheets, xlsxSheetEntry{Name: sheet.Name, SheetID: i + 1, RID: rID})
+		rels.Relationships = append(rels.Relationships, xlsxRelationship{
+			ID:     rID,
+			Type:   xlsxRelNS + "/worksheet",
+			Target: fmt.Sprintf("worksheets/sheet%d.xml", i+1),
+		})
+	}
+	rels.Relationships = append(rels.Relationships, xlsxRelationship{
+		ID:     fmt.Sprintf("rId%d", len(sheets)+1),
+		Type:   xlsxRelNS + "/styles",
+		Target: "styles.xml",
+	})
+
+	parts := []xlsxPart{
+		{"xl/workbook.xml", workbook},
+		{"xl/_rels/workbook.xml.rels", rels},
+		{"xl/styles.xml", xlsxStyles(data.Event.Currency)},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, xlsxPart{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.worksheet()})
+	}
+
+	for _, part := range parts {
+		body, err := xml.Marshal(part.v)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", part.name, err)
+		}
+		if err := writeZipPart(archive, part.name, append([]byte(xml.Header), body...)); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish workbook: %w", err)
+	}
+	return nil
+}
+
+// rosterSheet builds the Roster sheet from the artist list rows
+func rosterSheet(artists []models.EventArtist) xlsxSheet {
+	sheet := xlsxSheet{
+		Name:   "Roster",
+		Widths: []float64{14, 8, 8, 40},
+		Rows:   [][]xlsxCell{headerCells("Round-Easel", "Round", "Easel", "Artist Name")},
+	}
+	for _, row := range BuildRosterRows(artists) {
+		sheet.Rows = append(sheet.Rows, []xlsxCell{
+			textCell(row.RoundEasel),
+			numberCell(float64(row.RoundNumber), xlsxStyleDefault),
+			numberCell(float64(row.EaselNumber), xlsxStyleDefault),
+			textCell(row.ArtistName),
+		})
+	}
+	return sheet
+}
+
+// auctionSheet builds the Auction sheet from the auction table rows
+func auctionSheet(eventEID string, artists []models.EventArtist, auctionLots []models.AuctionLot, showBidders bool) xlsxSheet {
+	header := []string{"EID-Round-Easel", "Artist Name", "Bids", "Top Bid"}
+	widths := []float64{18, 40, 8, 14}
+	if showBidders {
+		header = append(header, "Bidder", "Payment Status")
+		widths = append(widths, 36, 18)
+	}
+
+	sheet := xlsxSheet{Name: "Auction", Widths: widths, Rows: [][]xlsxCell{headerCells(header...)}}
+	for _, row := range BuildAuctionRows(eventEID, artists, auctionLots) {
+		topBid := textCell("")
+		if row.TopBid > 0 {
+			topBid = numberCell(row.TopBid, xlsxStyleCurrency)
+		}
+
+		cells := []xlsxCell{
+			textCell(row.ID),
+			textCell(row.ArtistName),
+			numberCell(float64(row.BidCount), xlsxStyleDefault),
+			topBid,
+		}
+		if showBidders {
+			cells = append(cells, textCell(row.Bidder), textCell(row.PaymentStatus))
+		}
+		sheet.Rows = append(sheet.Rows, cells)
+	}
+	return sheet
+}
+
+// bidsSheet lists every bid of every lot from the bid history, oldest first within each lot
+func bidsSheet(eventEID string, artists []models.EventArtist, auctionLots []models.AuctionLot, showBidders bool, loc *time.Location) xlsxSheet {
+	header := []string{"EID-Round-Easel", "Artist Name", "Bid Time", "Amount", "Winning"}
+	widths := []float64{18, 40, 20, 14, 10}
+	if showBidders {
+		header = append(header, "Bidder", "Bidder Email", "Bidder Phone", "Payment Status")
+		widths = append(widths, 28, 32, 18, 18)
+	}
+
+	sheet := xlsxSheet{Name: "Bids", Widths: widths, Rows: [][]xlsxCell{headerCells(header...)}}
+	for _, lot := range BuildBidHistory(eventEID, artists, auctionLots) {
+		for _, row := range lot.Bids {
+			bidTime := textCell("")
+			if !row.Time.IsZero() {
+				bidTime = numberCell(excelSerial(row.Time.In(loc)), xlsxStyleDateTime)
+			}
+			winning := "No"
+			if row.Winning {
+				winning = "Yes"
+			}
+
+			cells := []xlsxCell{
+				textCell(lot.ID),
+				textCell(lot.ArtistName),
+				bidTime,
+				numberCell(row.Amount, xlsxStyleCurrency),
+				textCell(winning),
+			}
+			if showBidders {
+				// The sheet shows what the profile allows, not the masked label the PDF prints
+				cells = append(cells,
+					textCell(row.Bid.BidderName),
+					textCell(row.Bid.BidderEmail),
+					textCell(row.Bid.BidderPhone),
+					textCell(row.Bid.PaymentStatus))
+			}
+			sheet.Rows = append(sheet.Rows, cells)
+		}
+	}
+	return sheet
+}
+
+// worksheet assigns cell references and builds the serializable worksheet
+func (s xlsxSheet) worksheet() xlsxWorksheet {
+	ws := xlsxWorksheet{
+		Xmlns: xlsxMainNS,
+		SheetView: xlsxSheetView{
+			Pane: xlsxPane{YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft", State: "frozen"},
+		},
+	}
+	for i, width := range s.Widths {
+		ws.Cols = append(ws.Cols, xlsxCol{Min: i + 1, Max: i + 1, Width: width, CustomWidth: 1})
+	}
+	for r, cells := range s.Rows {
+		row := xlsxRow{R: r + 1, Cells: make([]xlsxCell, len(cells))}
+		for c, cell := range cells {
+			cell.Ref = columnName(c) + strconv.Itoa(r+1)
+			row.Cells[c] = cell
+		}
+		ws.Rows = append(ws.Rows, row)
+	}
+	return ws
+}
+
+// xlsxStyles builds the stylesheet with the event's currency format
+func xlsxStyles(currency string) xlsxStyleSheet {
+	return xlsxStyleSheet{
+		Xmlns: xlsxMainNS,
+		NumFmts: xlsxNumFmts{Count: 2, NumFmts: []xlsxNumFmt{
+			{ID: 164, Code: fmt.Sprintf(`"%s"#,##0.00`, currencySymbol(currency))},
+			{ID: 165, Code: "yyyy-mm-dd hh:mm:ss"},
+		}},
+		Fonts: xlsxFonts{Count: 2, Fonts: []xlsxFont{
+			{Size: xlsxVal{"11"}, Name: xlsxVal{"Calibri"}},
+			{Bold: &struct{}{}, Size: xlsxVal{"11"}, Name: xlsxVal{"Calibri"}},
+		}},
+		// The first two fills are reserved by Excel
+		Fills:   xlsxFills{Count: 2, Fills: []xlsxFill{{xlsxPatternFill{"none"}}, {xlsxPatternFill{"gray125"}}}},
+		Borders: xlsxBorders{Count: 1, Borders: []struct{}{{}}},
+		CellXfs: xlsxCellXfs{Count: 4, Xfs: []xlsxXf{
+			xlsxStyleDefault:  {},
+			xlsxStyleHeader:   {FontID: 1, ApplyFont: 1},
+			xlsxStyleCurrency: {NumFmtID: 164, ApplyNumberFormat: 1},
+			xlsxStyleDateTime: {NumFmtID: 165, ApplyNumberFormat: 1},
+		}},
+	}
+}
+
+// headerCells builds a bold header row
+func headerCells(titles ...string) []xlsxCell {
+	cells := make([]xlsxCell, len(titles))
+	for i, title := range titles {
+		cells[i] = textCell(title)
+		cells[i].Style = xlsxStyleHeader
+	}
+	return cells
+}
+
+// textCell builds an inline string cell
+func textCell(text string) xlsxCell {
+	return xlsxCell{Type: "inlineStr", Inline: &xlsxInlineString{Text: text}}
+}
+
+// numberCell builds a numeric cell with the given style
+func numberCell(value float64, style int) xlsxCell {
+	return xlsxCell{Style: style, Value: strconv.FormatFloat(value, 'f', -1, 64)}
+}
+
+// columnName converts a zero-based column index to its letters (0 -> A, 26 -> AA)
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// excelSerial converts a wall-clock time to an Excel serial date (days since 1899-12-30)
+func excelSerial(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	return wall.Sub(epoch).Hours() / 24
+}
+
+// writeZipPart adds one part to the package
+func writeZipPart(archive *zip.Writer, name string, body []byte) error {
+	f, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := f.Write(body); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"paperwork-service/internal/models"
+)
+
+// readWorkbook writes the workbook and returns its parts by name
+func readWorkbook(t *testing.T, data *PaperworkData, profile Profile) map[string][]byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := WriteEventWorkbook(&buf, data, XLSXOptions{Profile: profile}); err != nil {
+		t.Fatalf("WriteEventWorkbook: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("workbook is not a ZIP: %v", err)
+	}
+	parts := make(map[string][]byte)
+	for _, f := range archive.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts[f.Name], err = io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("reading %s: %v", f.Name, err)
+		}
+	}
+	return parts
+}
+
+// testCell is a worksheet cell as a spreadsheet reads it
+type testCell struct {
+	Ref    string `xml:"r,attr"`
+	Style  int    `xml:"s,attr"`
+	Type   string `xml:"t,attr"`
+	Value  string `xml:"v"`
+	Inline string `xml:"is>t"`
+}
+
+// readSheet decodes a worksheet part into its cells by reference, e.g. "D2"
+func readSheet(t *testing.T, part []byte) map[string]testCell {
+	t.Helper()
+	var sheet struct {
+		Rows []struct {
+			Cells []testCell `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := xml.Unmarshal(part, &sheet); err != nil {
+		t.Fatalf("bad worksheet XML: %v", err)
+	}
+	cells := make(map[string]testCell)
+	for _, row := range sheet.Rows {
+		for _, cell := range row.Cells {
+			cells[cell.Ref] = cell
+		}
+	}
+	return cells
+}
+
+func workbookEvent() *PaperworkData {
+	start := time.Date(2025, 3, 14, 19, 0, 0, 0, time.UTC)
+	first := models.Bid{ID: "bid-1", Round: 1, EaselNumber: 1, Amount: 100, BidTime: start.Add(35 * time.Minute), BidderName: "Ray Runnerup"}
+	winning := models.Bid{ID: "bid-2", Round: 1, EaselNumber: 1, Amount: 1250.5, IsWinning: true, BidTime: start.Add(40 * time.Minute),
+		BidderName: "Wendy Winner", BidderEmail: "wendy@example.com", PaymentStatus: "paid"}
+	return &PaperworkData{
+		Event: models.Event{EID: "AB4000", Name: "Workbook Night", Currency: "EUR", TimezoneIcann: "America/Toronto", EventStartDatetime: start},
+		Artists: []models.EventArtist{
+			{RoundNumber: 1, EaselNumber: 1, ArtistName: "Alma Artist"},
+			{RoundNumber: 1, EaselNumber: 2, ArtistName: "Bo Brush"},
+		},
+		AuctionLots: []models.AuctionLot{
+			{Round: 1, EaselNumber: 1, BidCount: 2, HighestBid: 1250.5, WinningBid: &winning, AllBids: []models.Bid{winning, first}},
+		},
+	}
+}
+
+func TestWriteEventWorkbookPackage(t *testing.T) {
+	parts := readWorkbook(t, workbookEvent(), ProfileStaff)
+
+	for _, name := range []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels",
+		"xl/styles.xml",
+		"xl/worksheets/sheet1.xml",
+		"xl/worksheets/sheet2.xml",
+		"xl/worksheets/sheet3.xml",
+	} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("package is missing %s", name)
+		}
+	}
+	if len(parts) != 8 {
+		t.Errorf("package has %d parts, want 8", len(parts))
+	}
+
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := xml.Unmarshal(parts["xl/workbook.xml"], &workbook); err != nil {
+		t.Fatalf("bad workbook.xml: %v", err)
+	}
+	var names, rIDs []string
+	for _, sheet := range workbook.Sheets {
+		names = append(names, sheet.Name)
+		rIDs = append(rIDs, sheet.RID)
+	}
+	if want := []string{"Roster", "Auction", "Bids"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("sheet names = %q, want %q", names, want)
+	}
+
+	// Every sheet relationship points at a worksheet part in the package
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := xml.Unmarshal(parts["xl/_rels/workbook.xml.rels"], &rels); err != nil {
+		t.Fatalf("bad workbook.xml.rels: %v", err)
+	}
+	targets := make(map[string]string)
+	for _, rel := range rels.Relationships {
+		targets[rel.ID] = rel.Target
+	}
+	for _, rID := range rIDs {
+		if _, ok := parts["xl/"+targets[rID]]; !ok {
+			t.Errorf("sheet %s points at missing part %q", rID, targets[rID])
+		}
+	}
+}
+
+func TestWriteEventWorkbookStyles(t *testing.T) {
+	parts := readWorkbook(t, workbookEvent(), ProfileStaff)
+
+	var styles struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		Xfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if err := xml.Unmarshal(parts["xl/styles.xml"], &styles); err != nil {
+		t.Fatalf("bad styles.xml: %v", err)
+	}
+	formats := make(map[int]string)
+	for _, numFmt := range styles.NumFmts {
+		formats[numFmt.ID] = numFmt.Code
+	}
+	if got := formats[styles.Xfs[xlsxStyleCurrency].NumFmtID]; got != `"€"#,##0.00` {
+		t.Errorf("currency style format = %q", got)
+	}
+	if got := formats[styles.Xfs[xlsxStyleDateTime].NumFmtID]; got != "yyyy-mm-dd hh:mm:ss" {
+		t.Errorf("date style format = %q", got)
+	}
+}
+
+func TestWriteEventWorkbookCells(t *testing.T) {
+	parts := readWorkbook(t, workbookEvent(), ProfileStaff)
+
+	number := func(cell testCell) float64 {
+		t.Helper()
+		if cell.Type != "" {
+			t.Errorf("cell %s has type %q, want a number", cell.Ref, cell.Type)
+		}
+		value, err := strconv.ParseFloat(cell.Value, 64)
+		if err != nil {
+			t.Errorf("cell %s = %q, want a number", cell.Ref, cell.Value)
+		}
+		return value
+	}
+
+	roster := readSheet(t, parts["xl/worksheets/sheet1.xml"])
+	if cell := roster["A2"]; cell.Type != "inlineStr" || cell.Inline != "1-1" {
+		t.Errorf("Roster A2 = %+v, want the text 1-1", cell)
+	}
+	if got := number(roster["C3"]); got != 2 {
+		t.Errorf("Roster easel = %v, want 2", got)
+	}
+
+	auction := readSheet(t, parts["xl/worksheets/sheet2.xml"])
+	if cell := auction["D2"]; number(cell) != 1250.5 || cell.Style != xlsxStyleCurrency {
+		t.Errorf("Auction top bid = %+v, want 1250.5 in the currency style", cell)
+	}
+	if cell := auction["D3"]; cell.Type != "inlineStr" || cell.Inline != "" {
+		t.Errorf("Auction top bid without bids = %+v, want an empty text cell", cell)
+	}
+	if got := auction["E2"].Inline; got != "Wendy Winner" {
+		t.Errorf("Auction bidder = %q", got)
+	}
+
+	// Bids are oldest first, times in event-local wall clock as Excel serial dates
+	bids := readSheet(t, parts["xl/worksheets/sheet3.xml"])
+	if cell := bids["D2"]; number(cell) != 100 || cell.Style != xlsxStyleCurrency {
+		t.Errorf("first bid amount = %+v, want 100 in the currency style", cell)
+	}
+	cell := bids["C2"]
+	if cell.Style != xlsxStyleDateTime {
+		t.Errorf("bid time style = %d, want %d", cell.Style, xlsxStyleDateTime)
+	}
+	// 2025-03-14 19:35 UTC is 15:35 in Toronto (EDT)
+	wantSerial := 45730 + (15*60+35)/(24*60.0)
+	if got := number(cell); got < wantSerial-1e-6 || got > wantSerial+1e-6 {
+		t.Errorf("bid time serial = %v, want %v", got, wantSerial)
+	}
+	if got := bids["E3"].Inline; got != "Yes" {
+		t.Errorf("winning flag on the last bid = %q, want Yes", got)
+	}
+}
+
+func TestWriteEventWorkbookPublicColumns(t *testing.T) {
+	parts := readWorkbook(t, workbookEvent(), ProfilePublic)
+
+	auction := readSheet(t, parts["xl/worksheets/sheet2.xml"])
+	if _, ok := auction["E1"]; ok {
+		t.Error("public Auction sheet has a bidder column")
+	}
+	bids := readSheet(t, parts["xl/worksheets/sheet3.xml"])
+	if _, ok := bids["F1"]; ok {
+		t.Error("public Bids sheet has bidder columns")
+	}
+}
+
+func TestExcelSerial(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		want float64
+	}{
+		{time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC), 0},
+		{time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC), 61},
+		{time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC), 45730.5},
+		// The wall clock is kept, whatever the zone
+		{time.Date(2025, 3, 14, 12, 0, 0, 0, time.FixedZone("EDT", -4*3600)), 45730.5},
+	}
+	for _, tt := range tests {
+		if got := excelSerial(tt.t); got != tt.want {
+			t.Errorf("excelSerial(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestBidsSheetFollowsBidHistory(t *testing.T) {
+	start := time.Date(2025, 3, 14, 19, 0, 0, 0, time.UTC)
+	artists := []models.EventArtist{
+		{RoundNumber: 2, EaselNumber: 3, ArtistName: "Stand By", Status: "confirmed-only"},
+		{RoundNumber: 2, EaselNumber: 3, ArtistName: "Cleo Canvas"},
+	}
+	// Two bids at the same time, the winner flagged only through WinningBid
+	winning := models.Bid{ID: "bid-2", Amount: 200, BidTime: start}
+	lots := []models.AuctionLot{{
+		Round: 2, EaselNumber: 3, WinningBid: &winning,
+		AllBids: []models.Bid{winning, {ID: "bid-1", Amount: 150, BidTime: start}},
+	}}
+
+	history := BuildBidHistory("AB4000", artists, lots)
+	sheet := bidsSheet("AB4000", artists, lots, false, time.UTC)
+	if len(sheet.Rows) != 1+len(history[0].Bids) {
+		t.Fatalf("Bids sheet has %d rows, want a header and %d bids", len(sheet.Rows), len(history[0].Bids))
+	}
+	for i, row := range history[0].Bids {
+		cells := sheet.Rows[i+1]
+		winningText := "No"
+		if row.Winning {
+			winningText = "Yes"
+		}
+		got := []string{cells[0].Inline.Text, cells[1].Inline.Text, cells[3].Value, cells[4].Inline.Text}
+		want := []string{"AB4000-2-3", "Cleo Canvas", strconv.FormatFloat(row.Amount, 'f', -1, 64), winningText}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("row %d = %q, want %q", i+1, got, want)
+		}
+	}
+	if got := sheet.Rows[2][4].Inline.Text; got != "Yes" {
+		t.Errorf("winning bid marked %q, want Yes", got)
+	}
+}